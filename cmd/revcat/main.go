@@ -1,6 +1,7 @@
 package main
 
 import (
+	"crypto/rand"
 	"crypto/tls"
 	"flag"
 	"fmt"
@@ -65,6 +66,20 @@ func main() {
 		Client:       []*config.Client{},
 		ElasticSearch: config.ElasticSearchConfig{
 			Debug: false,
+			Highlight: config.HighlightConfig{
+				FragmentSize: 150,
+				Fragments:    3,
+				InnerHits:    5,
+			},
+			Suggest: config.SuggestConfig{
+				Size: 3,
+			},
+		},
+		Embedder: config.EmbedderConfig{
+			CacheSize: 1000,
+		},
+		Thema: config.ThemaConfig{
+			Field: "category.keyword",
 		},
 	}
 
@@ -142,9 +157,45 @@ func main() {
 		cert = &c
 	}
 
+	cursorKey := []byte(conf.CursorKey)
+	if len(cursorKey) == 0 {
+		logger.Warn().Msg("no cursor key configured, cursors become invalid on restart")
+		cursorKey = make([]byte, 32)
+		if _, err := rand.Read(cursorKey); err != nil {
+			logger.Fatal().Msgf("cannot create cursor key: %v", err)
+		}
+	}
+
+	var embedder resolver.Embedder
+	if conf.Embedder.Endpoint != "" {
+		embedder = resolver.NewHTTPEmbedder(conf.Embedder.Endpoint, conf.Embedder.Model, string(conf.Embedder.ApiKey), time.Duration(conf.Embedder.Timeout))
+		if conf.Embedder.CacheSize > 0 {
+			embedder = resolver.NewCachedEmbedder(embedder, conf.Embedder.CacheSize)
+		}
+	}
+
+	var thema *resolver.Thema
+	if conf.Thema.File != "" {
+		thema, err = resolver.NewThema(conf.Thema.File, conf.Thema.Field, conf.Thema.Prefix)
+		if err != nil {
+			logger.Fatal().Msgf("cannot load thema taxonomy: %v", err)
+		}
+		reload := make(chan os.Signal, 1)
+		signal.Notify(reload, syscall.SIGHUP)
+		go func() {
+			for range reload {
+				if err := thema.Reload(); err != nil {
+					logger.Error().Err(err).Msg("cannot reload thema taxonomy")
+					continue
+				}
+				logger.Info().Msgf("thema taxonomy %s reloaded", conf.Thema.File)
+			}
+		}()
+	}
+
 	var serverResolver resolver.Resolver
 	if !*local {
-		serverResolver = resolver.NewElasticResolver(elastic, conf.ElasticSearch.Index, conf.Client, logger)
+		serverResolver = resolver.NewElasticResolver(elastic, conf.ElasticSearch.Index, time.Duration(conf.ElasticSearch.PITKeepAlive), cursorKey, embedder, conf.ElasticSearch.Highlight, conf.ElasticSearch.Suggest, thema, conf.SearchProfile, conf.Client, logger)
 	} else {
 		options := badger.DefaultOptions(conf.Badger)
 		if runtime.GOOS != "windows" {
@@ -155,7 +206,7 @@ func main() {
 			logger.Panic().Err(err).Msg("cannot open badger database")
 		}
 		defer db.Close()
-		serverResolver = resolver.NewBadgerResolver(logger, db)
+		serverResolver = resolver.NewBadgerResolver(logger, db, cursorKey, thema, conf.Client)
 	}
 
 	ctrl := server.NewController(conf.LocalAddr, conf.ExternalAddr, cert, serverResolver, conf.Client, logger)
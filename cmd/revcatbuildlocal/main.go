@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"crypto/tls"
+	"emperror.dev/errors"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"github.com/andybalholm/brotli"
@@ -14,6 +16,7 @@ import (
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
 	"github.com/je4/revcat/v2/config"
 	"github.com/je4/revcat/v2/pkg/resolver"
+	"github.com/je4/revcat/v2/pkg/sourcetype"
 	"github.com/je4/utils/v2/pkg/zLogger"
 	"github.com/rs/zerolog"
 	"io"
@@ -23,11 +26,14 @@ import (
 	"net/http/httputil"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 )
 
 var configfile = flag.String("config", "", "location of toml configuration file")
 var clientParam = flag.String("client", "test", "client name")
+var incremental = flag.Bool("incremental", false, "only fetch records changed since the last run")
 
 type LoggingHttpElasticClient struct {
 	c http.Client
@@ -155,40 +161,65 @@ func main() {
 		logger.Panic().Err(err).Msg("cannot build base filter")
 	}
 
+	var since time.Time
+	if *incremental {
+		if err := db.View(func(txn *badger.Txn) error {
+			since, err = resolver.BadgerSyncTimestamp(txn)
+			return err
+		}); err != nil {
+			logger.Panic().Err(err).Msg("cannot read sync timestamp")
+		}
+		if since.IsZero() {
+			logger.Info().Msg("no previous sync found, fetching all records")
+		} else {
+			logger.Info().Msgf("fetching records changed since %s", since.Format(time.RFC3339))
+		}
+	}
+
 	var query = &types.Query{
 		Bool: &types.BoolQuery{
-			Filter: baseQueries,
+			Filter: slices.Clone(baseQueries),
 		},
 	}
+	if !since.IsZero() {
+		query.Bool.Filter = append(query.Bool.Filter, types.Query{
+			Range: map[string]types.RangeQuery{
+				"timestamp": types.DateRangeQuery{
+					Gte: new(since.Format(time.RFC3339Nano)),
+				},
+			},
+		})
+	}
 
-	var sort = types.SortOptions{
-		SortOptions: map[string]types.FieldSort{
-			"_score": types.FieldSort{
-				Order: &sortorder.Desc},
-			"signature.keyword": types.FieldSort{
-				Order: &sortorder.Asc},
-		},
+	// search_after needs the sort fields in a fixed order
+	var sort = []types.SortCombinations{
+		types.SortOptions{SortOptions: map[string]types.FieldSort{
+			"_score": {Order: &sortorder.Desc},
+		}},
+		types.SortOptions{SortOptions: map[string]types.FieldSort{
+			"signature.keyword": {Order: &sortorder.Asc},
+		}},
 	}
 	var searchAfter = []types.FieldValue{}
 	var counter int64
+	var added, updated, removed int64
+	var maxTimestamp = since
 	for {
-		result, err := elastic.Search().Query(query).Sort(sort).SearchAfter(searchAfter...).Index(conf.ElasticSearch.Index).Do(context.Background())
+		result, err := elastic.Search().Query(query).Sort(sort...).SearchAfter(searchAfter...).Index(conf.ElasticSearch.Index).Do(context.Background())
 
 		if err != nil {
-			logger.Panic().Err(err).Msgf(err.Error())
+			logger.Panic().Err(err).Msg("cannot search")
 		}
 		if len(result.Hits.Hits) == 0 {
 			break
 		}
-		db.Update(func(txn *badger.Txn) error {
+		if err := db.Update(func(txn *badger.Txn) error {
 			for _, doc := range result.Hits.Hits {
-				/*
-					source := sourcetype.SourceData{ID: doc.Id_}
-					if err := json.Unmarshal(doc.Source_, &source); err != nil {
-						logger.Panic().Err(err)
-					}
-
-				*/
+				id := *doc.Id_
+				source := sourcetype.SourceData{ID: id}
+				if err := json.Unmarshal(doc.Source_, &source); err != nil {
+					return errors.Wrapf(err, "cannot unmarshal %s", id)
+				}
 				buf := bytes.NewBuffer([]byte{})
 				bw := brotli.NewWriter(buf)
 				if _, err := bw.Write(doc.Source_); err != nil {
@@ -198,14 +229,133 @@ func main() {
 					logger.Panic().Err(err)
 
 				}
-				if err := txn.Set([]byte(doc.Id_), buf.Bytes()); err != nil {
-					logger.Panic().Err(err)
-				}
-				logger.Info().Msgf("[%05d]: %s", counter+1, doc.Id_)
 				counter++
 				searchAfter = doc.Sort
+				if source.Timestamp.After(maxTimestamp) {
+					maxTimestamp = source.Timestamp
+				}
+				// records with the timestamp of the last sync are fetched again, they are only stored if they changed
+				item, err := txn.Get([]byte(id))
+				switch {
+				case err == nil:
+					stored, err := item.ValueCopy(nil)
+					if err != nil {
+						return errors.Wrapf(err, "cannot read %s", id)
+					}
+					if bytes.Equal(stored, buf.Bytes()) {
+						logger.Debug().Msgf("[%05d]: %s unchanged", counter, id)
+						continue
+					}
+					updated++
+				case errors.Is(err, badger.ErrKeyNotFound):
+					added++
+				default:
+					return errors.Wrapf(err, "cannot check %s", id)
+				}
+				if err := txn.Set([]byte(id), buf.Bytes()); err != nil {
+					logger.Panic().Err(err)
+				}
+				if err := resolver.WriteBadgerIndex(txn, id, &source); err != nil {
+					return errors.Wrapf(err, "cannot index %s", id)
+				}
+				logger.Info().Msgf("[%05d]: %s", counter, id)
 			}
 			return nil
-		})
+		}); err != nil {
+			logger.Panic().Err(err).Msg("cannot store records")
+		}
+	}
+
+	// records which were deleted or fell out of the client scope are removed locally.
+	// a full fetch checks all stored records, also those of databases built without index
+	var localIDs []string
+	var localCount int
+	if err := db.View(func(txn *badger.Txn) error {
+		if since.IsZero() {
+			localIDs = resolver.BadgerRecordIDs(txn)
+			localCount = len(localIDs)
+			return nil
+		}
+		localCount, err = resolver.BadgerDocCount(txn)
+		return err
+	}); err != nil {
+		logger.Panic().Err(err).Msg("cannot count local records")
+	}
+	remoteCount, err := elastic.Count().Index(conf.ElasticSearch.Index).Query(&types.Query{
+		Bool: &types.BoolQuery{Filter: baseQueries},
+	}).Do(context.Background())
+	if err != nil {
+		logger.Panic().Err(err).Msg("cannot count records")
+	}
+	// all remote records are stored locally, so only a difference in number reveals removed records
+	if int64(localCount) != remoteCount.Count {
+		remoteIDs, err := fetchIDs(elastic, conf.ElasticSearch.Index, baseQueries)
+		if err != nil {
+			logger.Panic().Err(err).Msg("cannot fetch record ids")
+		}
+		if localIDs == nil {
+			if err := db.View(func(txn *badger.Txn) error {
+				localIDs = resolver.BadgerIDs(txn)
+				return nil
+			}); err != nil {
+				logger.Panic().Err(err).Msg("cannot read local record ids")
+			}
+		}
+		for _, id := range localIDs {
+			if remoteIDs[id] {
+				continue
+			}
+			// every record is removed in its own transaction, the index keys of many records exceed the transaction size
+			if err := db.Update(func(txn *badger.Txn) error {
+				if err := resolver.DeleteBadgerIndex(txn, id); err != nil {
+					return errors.Wrapf(err, "cannot remove index of %s", id)
+				}
+				if err := txn.Delete([]byte(id)); err != nil {
+					return errors.Wrapf(err, "cannot remove %s", id)
+				}
+				return nil
+			}); err != nil {
+				logger.Panic().Err(err).Msg("cannot remove records")
+			}
+			logger.Info().Msgf("removed: %s", id)
+			removed++
+		}
+	}
+	if err := db.Update(func(txn *badger.Txn) error {
+		return resolver.SetBadgerSyncTimestamp(txn, maxTimestamp)
+	}); err != nil {
+		logger.Panic().Err(err).Msg("cannot store sync timestamp")
+	}
+
+	fmt.Printf("added: %d, updated: %d, removed: %d\n", added, updated, removed)
+}
+
+// fetchIDs returns the ids of all records matching the filter
+func fetchIDs(elastic *elasticsearch.TypedClient, index string, filter []types.Query) (map[string]bool, error) {
+	var query = &types.Query{
+		Bool: &types.BoolQuery{
+			Filter: filter,
+		},
+	}
+	var sort = types.SortOptions{
+		SortOptions: map[string]types.FieldSort{
+			"signature.keyword": types.FieldSort{
+				Order: &sortorder.Asc},
+		},
+	}
+	var ids = map[string]bool{}
+	var searchAfter = []types.FieldValue{}
+	for {
+		result, err := elastic.Search().Index(index).Query(query).Sort(sort).Source_(false).Size(1000).SearchAfter(searchAfter...).Do(context.Background())
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot search")
+		}
+		if len(result.Hits.Hits) == 0 {
+			return ids, nil
+		}
+		for _, doc := range result.Hits.Hits {
+			ids[*doc.Id_] = true
+			searchAfter = doc.Sort
+		}
 	}
 }
@@ -33,6 +33,7 @@ const HEIGHT = 150
 var configfile = flag.String("config", "", "location of toml configuration file")
 var clientParam = flag.String("client", "performance", "client name")
 var csvFile = flag.String("csv", "", "location of csv file")
+var dateReport = flag.String("datereport", "", "location of csv file with the dates, which cannot be parsed")
 
 type imgData struct {
 	signature string
@@ -53,6 +54,12 @@ func (l LoggingHttpElasticClient) RoundTrip(request *http.Request) (*http.Respon
 	return l.c.Do(request)
 }
 
+type dateError struct {
+	signature string
+	date      string
+	err       error
+}
+
 type statEntry struct {
 	Images      int64
 	Audio       int64
@@ -183,6 +190,7 @@ func main() {
 	var stats = map[string]*statEntry{
 		"total": {},
 	}
+	var dateErrors = []dateError{}
 	var mediaserverRegexp *regexp.Regexp = regexp.MustCompile("^mediaserver:([^/]+)/([^/]+)$")
 	for {
 		result, err := elastic.Search().Query(query).Sort(sort).SearchAfter(searchAfter...).Index(conf.ElasticSearch.Index).Do(context.Background())
@@ -207,6 +215,11 @@ func main() {
 				stats[source.CollectionTitle] = &statEntry{}
 			}
 			stats[source.CollectionTitle].Documents++
+			if source.Date != "" {
+				if _, err := sourcetype.ParseDate(source.Date); err != nil {
+					dateErrors = append(dateErrors, dateError{signature: source.Signature, date: source.Date, err: err})
+				}
+			}
 			if !source.HasMedia {
 				continue
 			}
@@ -263,7 +276,21 @@ func main() {
 			writer.Write([]string{k, fmt.Sprintf("%d", v.Documents), fmt.Sprintf("%d", v.Images), fmt.Sprintf("%d", v.Audio), fmt.Sprintf("%d", v.Video), fmt.Sprintf("%d", v.PDF), fmt.Sprintf("%d", v.VideoLength/(60)), fmt.Sprintf("%d", v.AudioLength/(60))})
 		}
 	}
+	if *dateReport != "" {
+		reportFP, err := os.Create(*dateReport)
+		if err != nil {
+			logger.Panic().Err(err).Msgf("cannot create date report %s", *dateReport)
+		}
+		defer reportFP.Close()
+		writer := csv.NewWriter(reportFP)
+		defer writer.Flush()
+		writer.Write([]string{"signature", "date", "error"})
+		for _, de := range dateErrors {
+			writer.Write([]string{de.signature, de.date, de.err.Error()})
+		}
+	}
 	fmt.Printf("found %d documents\n", counter)
+	fmt.Printf("found %d dates, which cannot be parsed\n", len(dateErrors))
 	fmt.Printf("found %d collections\n", len(stats))
 
 	for k, v := range stats {
@@ -18,20 +18,72 @@ type ClientANDQuery struct {
 }
 
 type Client struct {
-	Name      string           `toml:"name"`
-	Apikey    config.EnvString `toml:"apikey"`
-	Groups    []string         `toml:"groups"`
-	AND       []ClientANDQuery `toml:"and"`
-	JWTKey    config.EnvString `toml:"jwtkey"`
-	JWTAlgs   []string         `toml:"jwtalg"`
-	JWTMaxAge config.Duration  `toml:"jwtmaxage"`
+	Name        string           `toml:"name"`
+	Apikey      config.EnvString `toml:"apikey"`
+	Groups      []string         `toml:"groups"`
+	AND         []ClientANDQuery `toml:"and"`
+	JWTKey      config.EnvString `toml:"jwtkey"`
+	JWTAlgs     []string         `toml:"jwtalg"`
+	JWTMaxAge   config.Duration  `toml:"jwtmaxage"`
+	MaxPageSize int              `toml:"maxpagesize"`
+	// SearchProfile is the name of the search profile, the default profile is used if empty
+	SearchProfile string `toml:"searchprofile"`
+	// Admin allows the client to explain searches and to get the search requests
+	Admin bool `toml:"admin"`
+}
+
+type SearchShouldConfig struct {
+	Fields          []string `toml:"fields"`
+	AnalyzeWildcard bool     `toml:"analyzewildcard"`
+}
+
+type SearchProfile struct {
+	Name string `toml:"name"`
+	// Fields are searched by the search types without own fields, e.g. "all"
+	Fields    []string `toml:"fields"`
+	Operator  string   `toml:"operator"`
+	Fuzziness string   `toml:"fuzziness"`
+	// Should are the clauses, which boost hits without restricting them
+	Should []SearchShouldConfig `toml:"should"`
+	// Types are the fields per search type
+	Types          map[string][]string `toml:"types"`
+	NestedPrefixes []string            `toml:"nestedprefixes"`
+}
+
+type HighlightConfig struct {
+	FragmentSize int `toml:"fragmentsize"`
+	Fragments    int `toml:"fragments"`
+	// InnerHits is the number of matching nested documents returned per nested path, if highlights are requested
+	InnerHits int `toml:"innerhits"`
+}
+
+type SuggestConfig struct {
+	Threshold int `toml:"threshold"`
+	Size      int `toml:"size"`
 }
 
 type ElasticSearchConfig struct {
-	Endpoint []string         `toml:"endpoint"`
-	Index    string           `toml:"index"`
-	ApiKey   config.EnvString `toml:"apikey"`
-	Debug    bool             `toml:"debug"`
+	Endpoint     []string         `toml:"endpoint"`
+	Index        string           `toml:"index"`
+	ApiKey       config.EnvString `toml:"apikey"`
+	Debug        bool             `toml:"debug"`
+	PITKeepAlive config.Duration  `toml:"pitkeepalive"`
+	Highlight    HighlightConfig  `toml:"highlight"`
+	Suggest      SuggestConfig    `toml:"suggest"`
+}
+
+type EmbedderConfig struct {
+	Endpoint  string           `toml:"endpoint"`
+	Model     string           `toml:"model"`
+	ApiKey    config.EnvString `toml:"apikey"`
+	Timeout   config.Duration  `toml:"timeout"`
+	CacheSize int              `toml:"cachesize"`
+}
+
+type ThemaConfig struct {
+	File   string `toml:"file"`
+	Field  string `toml:"field"`
+	Prefix string `toml:"prefix"`
 }
 
 type ZoomConfig struct {
@@ -76,8 +128,16 @@ type RevCatConfig struct {
 	LogLevel string `toml:"loglevel"`
 	Badger   string `toml:"badger"`
 
+	CursorKey config.EnvString `toml:"cursorkey"`
+
 	ElasticSearch ElasticSearchConfig `toml:"elasticsearch"`
 
+	Embedder EmbedderConfig `toml:"embedder"`
+
+	Thema ThemaConfig `toml:"thema"`
+
+	SearchProfile []*SearchProfile `toml:"searchprofile"`
+
 	Client []*Client `toml:"client"`
 }
 
@@ -0,0 +1,388 @@
+package resolver
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/je4/revcat/v2/config"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// badgerIDSet is a set of record ids. a nil set does not restrict anything
+type badgerIDSet map[string]bool
+
+func (s badgerIDSet) contains(id string) bool {
+	return s == nil || s[id]
+}
+
+// badgerExcludeSet contains all records except its ids, it is the result of a not filter
+type badgerExcludeSet map[string]bool
+
+func (s badgerExcludeSet) contains(id string) bool {
+	return !s[id]
+}
+
+// badgerSet is the result of a filter, either a badgerIDSet or a badgerExcludeSet
+type badgerSet interface {
+	contains(id string) bool
+}
+
+// badgerTermField maps an elastic field name like "category.keyword" to the name in the local term index.
+// keyword fields are matched exactly, the text fields match on single tokens like an elastic match query
+func badgerTermField(field string) (name string, keyword bool, err error) {
+	name = field
+	if matches := nestedRegexp.FindStringSubmatch(field); len(matches) == 3 {
+		name = fmt.Sprintf("%s.%s", matches[1], matches[2])
+	}
+	name, keyword = strings.CutSuffix(name, ".keyword")
+	switch name {
+	case "category", "tags", "collectiontitle", "type", "mediatype", "acl.meta", "references.signature":
+		return name, keyword, nil
+	default:
+		return "", false, errors.Errorf("field '%s' not indexed in local index", field)
+	}
+}
+
+// badgerScan iterates over the values of a field in the term or token index, which start with valuePrefix,
+// and calls fn with the value and the record id
+func badgerScan(txn *badger.Txn, indexPrefix, field, valuePrefix string, fn func(value, id string)) {
+	fieldPrefix := []byte(indexPrefix + field + "/")
+	it := txn.NewIterator(badger.IteratorOptions{Prefix: append(bytes.Clone(fieldPrefix), valuePrefix...)})
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		valueID := it.Item().Key()[len(fieldPrefix):]
+		pos := bytes.IndexByte(valueID, 0)
+		if pos < 0 {
+			continue
+		}
+		fn(string(valueID[:pos]), string(valueID[pos+1:]))
+	}
+}
+
+// badgerTermValues iterates over all values of a field in the term index and calls fn with the value and the record id
+func badgerTermValues(txn *badger.Txn, field string, fn func(value, id string)) {
+	badgerScan(txn, badgerPrefixTerm, field, "", fn)
+}
+
+// badgerTermIDs returns the records with the value in the field.
+// keyword fields match the value exactly, text fields match one of the tokens of the value
+func badgerTermIDs(txn *badger.Txn, field, value string, keyword bool) badgerIDSet {
+	var result = badgerIDSet{}
+	collect := func(_, id string) { result[id] = true }
+	if keyword {
+		badgerScan(txn, badgerPrefixTerm, field, value+"\x00", collect)
+		return result
+	}
+	for _, token := range badgerTokenize(value) {
+		badgerScan(txn, badgerPrefixTok, field, token+"\x00", collect)
+	}
+	return result
+}
+
+// badgerWildcardRegexp translates an elastic wildcard pattern with * and ? to a regular expression
+func badgerWildcardRegexp(pattern string, caseInsensitive bool) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	if caseInsensitive {
+		sb.WriteString("(?i)")
+	}
+	sb.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// badgerMatcher matches the values starting with prefix, match further restricts them if set
+type badgerMatcher struct {
+	prefix string
+	match  func(value string) bool
+}
+
+// badgerMatchIDs returns the records with a value of the field, which matches one of the matchers.
+// keyword fields match the whole value, text fields the single tokens like an elastic prefix or wildcard query
+func badgerMatchIDs(txn *badger.Txn, fieldName string, matchers []badgerMatcher) (badgerIDSet, error) {
+	field, keyword, err := badgerTermField(fieldName)
+	if err != nil {
+		return nil, err
+	}
+	indexPrefix := badgerPrefixTok
+	if keyword {
+		indexPrefix = badgerPrefixTerm
+	}
+	var result = badgerIDSet{}
+	for _, m := range matchers {
+		badgerScan(txn, indexPrefix, field, m.prefix, func(value, id string) {
+			if m.match == nil || m.match(value) {
+				result[id] = true
+			}
+		})
+	}
+	return result, nil
+}
+
+// badgerFilterIDs evaluates a filter against the term index, analogous to createFilterQuery
+func badgerFilterIDs(txn *badger.Txn, filter *model.InFilter) (badgerSet, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if filter.NotTerm != nil {
+		ids, err := badgerFilterIDs(txn, filter.NotTerm)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid notTerm")
+		}
+		switch set := ids.(type) {
+		case badgerExcludeSet:
+			return badgerIDSet(set), nil
+		case badgerIDSet:
+			if set == nil {
+				return badgerIDSet{}, nil
+			}
+			return badgerExcludeSet(set), nil
+		default:
+			return nil, errors.Errorf("unknown filter result %T", ids)
+		}
+	}
+	if filter.PrefixTerm != nil {
+		var matchers = []badgerMatcher{}
+		for _, val := range filter.PrefixTerm.Values {
+			matchers = append(matchers, badgerMatcher{prefix: val})
+		}
+		return badgerMatchIDs(txn, filter.PrefixTerm.Field, matchers)
+	}
+	if filter.WildcardTerm != nil {
+		var matchers = []badgerMatcher{}
+		for _, val := range filter.WildcardTerm.Values {
+			wildcardRegexp, err := badgerWildcardRegexp(val, filter.WildcardTerm.CaseInsensitive)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid wildcard pattern '%s'", val)
+			}
+			// only the values starting with the literal part of the pattern have to be matched
+			var prefix string
+			if !filter.WildcardTerm.CaseInsensitive {
+				prefix, _, _ = strings.Cut(val, "*")
+				prefix, _, _ = strings.Cut(prefix, "?")
+			}
+			matchers = append(matchers, badgerMatcher{prefix: prefix, match: wildcardRegexp.MatchString})
+		}
+		return badgerMatchIDs(txn, filter.WildcardTerm.Field, matchers)
+	}
+	if filter.ExistsTerm != nil {
+		field, _, err := badgerTermField(filter.ExistsTerm.Field)
+		if err != nil {
+			return nil, err
+		}
+		var result = badgerIDSet{}
+		badgerTermValues(txn, field, func(value, id string) {
+			result[id] = true
+		})
+		return result, nil
+	}
+	if filter.RangeTerm != nil {
+		return nil, errors.Errorf("range filter on '%s' not supported by local index", filter.RangeTerm.Field)
+	}
+	if filter.HierarchyTerm != nil && len(filter.HierarchyTerm.Values) > 0 {
+		field, _, err := badgerTermField(filter.HierarchyTerm.Field)
+		if err != nil {
+			return nil, err
+		}
+		var result = badgerIDSet{}
+		for _, val := range filter.HierarchyTerm.Values {
+			badgerScan(txn, badgerPrefixTerm, field, val, func(value, id string) {
+				if value == val || strings.HasPrefix(value, val+filter.HierarchyTerm.Separator) {
+					result[id] = true
+				}
+			})
+		}
+		return result, nil
+	}
+	if filter.BoolTerm != nil && len(filter.BoolTerm.Values) > 0 {
+		field, keyword, err := badgerTermField(filter.BoolTerm.Field)
+		if err != nil {
+			return nil, err
+		}
+		var result badgerIDSet
+		for _, val := range filter.BoolTerm.Values {
+			ids := badgerTermIDs(txn, field, val, keyword)
+			switch {
+			case result == nil:
+				result = ids
+			case filter.BoolTerm.And:
+				for id := range result {
+					if !ids[id] {
+						delete(result, id)
+					}
+				}
+			default:
+				for id := range ids {
+					result[id] = true
+				}
+			}
+		}
+		return result, nil
+	}
+	return nil, errors.WithStack(newValidationError("unknown filter type"))
+}
+
+// badgerClientIDs evaluates the AND base filter of the client against the term index, analogous to BuildBaseFilter.
+// Clients filtering on fields, which are not in the local index, cannot be served by the local index.
+func badgerClientIDs(txn *badger.Txn, client *config.Client) (badgerIDSet, error) {
+	if client == nil {
+		return nil, nil
+	}
+	var result badgerIDSet
+	for _, and := range client.AND {
+		var ids = badgerIDSet{}
+		for _, q := range and.OR {
+			if q.Field == "" {
+				continue
+			}
+			field, keyword, err := badgerTermField(q.Field)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid base filter of client '%s'", client.Name)
+			}
+			for _, val := range q.Values {
+				for id := range badgerTermIDs(txn, field, val, keyword) {
+					ids[id] = true
+				}
+			}
+		}
+		if result == nil {
+			result = ids
+			continue
+		}
+		for id := range result {
+			if !ids[id] {
+				delete(result, id)
+			}
+		}
+	}
+	return result, nil
+}
+
+// badgerACLIDs returns the records, whose metadata is visible to one of the groups
+func badgerACLIDs(txn *badger.Txn, groups []string) badgerIDSet {
+	var result = badgerIDSet{}
+	for _, group := range groups {
+		for id := range badgerTermIDs(txn, "acl.meta", group, true) {
+			result[id] = true
+		}
+	}
+	return result
+}
+
+// badgerIntersect returns the records contained in all sets, it is nil if no set restricts the records.
+// the records of the smallest set are checked against the other sets, all records only if there are
+// excluding sets and no other restricting set
+func badgerIntersect(txn *badger.Txn, sets ...badgerSet) badgerIDSet {
+	var smallest badgerIDSet
+	var exclude = false
+	for _, set := range sets {
+		switch s := set.(type) {
+		case badgerIDSet:
+			if s != nil && (smallest == nil || len(s) < len(smallest)) {
+				smallest = s
+			}
+		case badgerExcludeSet:
+			exclude = true
+		}
+	}
+	if smallest == nil {
+		if !exclude {
+			return nil
+		}
+		smallest = badgerIDSet{}
+		for _, id := range BadgerIDs(txn) {
+			smallest[id] = true
+		}
+	}
+	var result = badgerIDSet{}
+	for id := range smallest {
+		found := true
+		for _, set := range sets {
+			if !set.contains(id) {
+				found = false
+				break
+			}
+		}
+		if found {
+			result[id] = true
+		}
+	}
+	return result
+}
+
+// badgerFacet counts the values of the facet field for the given records like an elastic terms aggregation
+func badgerFacet(txn *badger.Txn, term *model.InFacetTerm, infos map[string]*badgerDocInfo, ids []string) (*model.Facet, error) {
+	field, _, err := badgerTermField(term.Field)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid facet field of %s", term.Name)
+	}
+	var size = term.Size
+	var minDocCount = term.MinDocCount
+	var include func(string) bool
+	if len(term.Include) == 1 {
+		includeRegexp, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", term.Include[0]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid include pattern of %s", term.Name)
+		}
+		include = includeRegexp.MatchString
+	} else if len(term.Include) > 1 {
+		include = func(value string) bool { return slices.Contains(term.Include, value) }
+		size = len(term.Include)
+		minDocCount = 0
+	}
+
+	var counts = map[string]int{}
+	if minDocCount <= 0 {
+		badgerTermValues(txn, field, func(value, id string) {
+			counts[value] += 0
+		})
+	}
+	for _, id := range ids {
+		for _, value := range infos[id].Terms[field] {
+			counts[value]++
+		}
+	}
+	type bucket struct {
+		value string
+		count int
+	}
+	var buckets = []bucket{}
+	for value, count := range counts {
+		if count < minDocCount || (include != nil && !include(value)) {
+			continue
+		}
+		buckets = append(buckets, bucket{value: value, count: count})
+	}
+	slices.SortFunc(buckets, func(a, b bucket) int {
+		if c := cmp.Compare(b.count, a.count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.value, b.value)
+	})
+	facet := &model.Facet{
+		Name:   term.Name,
+		Values: make([]model.FacetValue, 0),
+	}
+	for _, b := range buckets[:min(size, len(buckets))] {
+		facet.Values = append(facet.Values, &model.FacetValueString{
+			StrVal: b.value,
+			Count:  b.count,
+		})
+	}
+	return facet, nil
+}
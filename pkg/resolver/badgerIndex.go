@@ -0,0 +1,519 @@
+package resolver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"emperror.dev/errors"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/je4/revcat/v2/pkg/sourcetype"
+	"go.ub.unibas.ch/metastring/pkg/multilangString"
+)
+
+// the records themselves are stored under their plain id. all index data lives
+// below a NUL prefix, which never occurs in a signature.
+const (
+	badgerPrefixFTS  = "\x00fts/"
+	badgerPrefixTerm = "\x00term/"
+	badgerPrefixTok  = "\x00tok/"
+	badgerPrefixDoc  = "\x00doc/"
+	badgerPrefixMeta = "\x00meta/"
+)
+
+const (
+	badgerKeySyncTimestamp = badgerPrefixMeta + "timestamp"
+	badgerKeyDocCount      = badgerPrefixMeta + "count"
+)
+
+// keyword values longer than this are not indexed, like "ignore_above" in the elastic mapping
+const badgerKeywordIgnoreAbove = 256
+
+// badgerDocInfo is stored for every indexed record. it remembers the index keys
+// written for the record, so that they can be removed on reindexing, and holds
+// everything needed to filter and sort hits without loading the record.
+type badgerDocInfo struct {
+	Keys  []string            `json:"keys"`
+	ACL   map[string][]string `json:"acl"`
+	Sort  map[string]string   `json:"sort"`
+	Terms map[string][]string `json:"terms"`
+}
+
+var htmlTagRegexp = regexp.MustCompile(`<[^>]*>`)
+
+func badgerTokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func multiLangStringValues(mls *multilangString.MultiLangString) []string {
+	if mls == nil {
+		return nil
+	}
+	var result = []string{}
+	for _, lang := range mls.GetNativeLanguages() {
+		result = append(result, mls.Get(lang).ContentString())
+	}
+	for _, lang := range mls.GetTranslatedLanguages() {
+		result = append(result, mls.Get(lang).ContentString())
+	}
+	return result
+}
+
+// badgerFullTextFields extracts the text of all fulltext indexed fields.
+// the field names follow the elastic mapping, so that search types can use the same names
+func badgerFullTextFields(src *sourcetype.SourceData) map[string][]string {
+	fields := map[string][]string{
+		"title":           multiLangStringValues(src.Title),
+		"abstract":        multiLangStringValues(src.Abstract),
+		"collectiontitle": {src.CollectionTitle},
+		"series":          {src.Series},
+		"signature":       {src.Signature},
+		"tags":            src.Tags,
+		"category":        src.Category,
+	}
+	for _, person := range src.Persons {
+		fields["persons.name"] = append(fields["persons.name"], person.Name)
+		fields["persons.name"] = append(fields["persons.name"], person.AlternativeNames...)
+	}
+	for _, note := range src.Notes {
+		fields["notes.title"] = append(fields["notes.title"], note.Title)
+		fields["notes.note"] = append(fields["notes.note"], htmlTagRegexp.ReplaceAllString(string(note.Note), " "))
+	}
+	for _, ml := range src.Media {
+		for _, media := range ml {
+			if media.Fulltext != "" {
+				fields["media.fulltext"] = append(fields["media.fulltext"], media.Fulltext)
+			}
+		}
+	}
+	return fields
+}
+
+// badgerTermFields extracts the values of all keyword indexed fields, which can be used for filters and facets
+func badgerTermFields(src *sourcetype.SourceData) map[string][]string {
+	fields := map[string][]string{
+		"category":        src.Category,
+		"tags":            src.Tags,
+		"collectiontitle": {src.CollectionTitle},
+		"type":            {src.Type},
+		"mediatype":       src.Mediatype,
+	}
+	for t, acls := range src.ACL {
+		if strings.ToLower(t) == "meta" {
+			fields["acl.meta"] = append(fields["acl.meta"], acls...)
+		}
+	}
+	// the term index of the references is the reverse reference index
+	for _, ref := range src.References {
+		fields["references.signature"] = append(fields["references.signature"], ref.Signature)
+	}
+	for field, values := range fields {
+		var result = []string{}
+		for _, value := range values {
+			if value == "" || utf8.RuneCountInString(value) > badgerKeywordIgnoreAbove {
+				continue
+			}
+			result = append(result, value)
+		}
+		slices.Sort(result)
+		fields[field] = slices.Compact(result)
+	}
+	return fields
+}
+
+func badgerDocKey(id string) []byte {
+	return []byte(badgerPrefixDoc + id)
+}
+
+func badgerFTSKey(field, term, id string) []byte {
+	return []byte(badgerPrefixFTS + field + "/" + term + "\x00" + id)
+}
+
+func badgerTermKey(field, value, id string) []byte {
+	return []byte(badgerPrefixTerm + field + "/" + value + "\x00" + id)
+}
+
+// badgerTokKey is the key of a token of a term field, it is used for matching the text field like a match query
+func badgerTokKey(field, token, id string) []byte {
+	return []byte(badgerPrefixTok + field + "/" + token + "\x00" + id)
+}
+
+func badgerLoadDocInfo(txn *badger.Txn, id string) (*badgerDocInfo, error) {
+	item, err := txn.Get(badgerDocKey(id))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get index info of %s", id)
+	}
+	info := &badgerDocInfo{}
+	if err := item.Value(func(val []byte) error {
+		return json.Unmarshal(val, info)
+	}); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal index info of %s", id)
+	}
+	return info, nil
+}
+
+// DeleteBadgerIndex removes all index entries of the record with the given id
+func DeleteBadgerIndex(txn *badger.Txn, id string) error {
+	info, err := badgerLoadDocInfo(txn, id)
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return nil
+		}
+		return err
+	}
+	// the count has to be updated first, it is counted from the index info keys if it is missing
+	if err := badgerAddDocCount(txn, -1); err != nil {
+		return err
+	}
+	for _, key := range info.Keys {
+		if err := txn.Delete([]byte(key)); err != nil {
+			return errors.Wrapf(err, "cannot delete index key of %s", id)
+		}
+	}
+	if err := txn.Delete(badgerDocKey(id)); err != nil {
+		return errors.Wrapf(err, "cannot delete index info of %s", id)
+	}
+	return nil
+}
+
+// WriteBadgerIndex (re)indexes the record with the given id.
+// it has to be called in the same transaction which stores the record itself
+func WriteBadgerIndex(txn *badger.Txn, id string, src *sourcetype.SourceData) error {
+	if err := DeleteBadgerIndex(txn, id); err != nil {
+		return errors.Wrapf(err, "cannot remove old index of %s", id)
+	}
+	info := &badgerDocInfo{
+		Keys:  []string{},
+		ACL:   map[string][]string{},
+		Terms: badgerTermFields(src),
+		Sort: map[string]string{
+			"signature":       src.Signature,
+			"title":           src.GetTitle().ContentString(),
+			"date":            src.Date,
+			"collectiontitle": src.CollectionTitle,
+			"series":          src.Series,
+			"dateadded":       src.DateAdded.UTC().Format(time.RFC3339),
+			"timestamp":       src.Timestamp.UTC().Format(time.RFC3339),
+		},
+	}
+	if date, err := src.GetDateInterval(); err == nil {
+		info.Sort["datestart"] = date.Start.Format(time.DateOnly)
+		info.Sort["dateend"] = date.End.Format(time.DateOnly)
+	}
+	for t, acls := range src.ACL {
+		info.ACL[strings.ToLower(t)] = acls
+	}
+	for field, texts := range badgerFullTextFields(src) {
+		var tf = map[string]uint64{}
+		for _, text := range texts {
+			for _, term := range badgerTokenize(text) {
+				tf[term]++
+			}
+		}
+		for term, count := range tf {
+			key := badgerFTSKey(field, term, id)
+			if err := txn.Set(key, binary.AppendUvarint(nil, count)); err != nil {
+				return errors.Wrapf(err, "cannot write index key of %s", id)
+			}
+			info.Keys = append(info.Keys, string(key))
+		}
+	}
+	for field, values := range info.Terms {
+		var tokens = []string{}
+		for _, value := range values {
+			key := badgerTermKey(field, value, id)
+			if err := txn.Set(key, nil); err != nil {
+				return errors.Wrapf(err, "cannot write index key of %s", id)
+			}
+			info.Keys = append(info.Keys, string(key))
+			tokens = append(tokens, badgerTokenize(value)...)
+		}
+		slices.Sort(tokens)
+		for _, token := range slices.Compact(tokens) {
+			key := badgerTokKey(field, token, id)
+			if err := txn.Set(key, nil); err != nil {
+				return errors.Wrapf(err, "cannot write index key of %s", id)
+			}
+			info.Keys = append(info.Keys, string(key))
+		}
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal index info of %s", id)
+	}
+	if err := badgerAddDocCount(txn, 1); err != nil {
+		return err
+	}
+	if err := txn.Set(badgerDocKey(id), data); err != nil {
+		return errors.Wrapf(err, "cannot write index info of %s", id)
+	}
+	return nil
+}
+
+// badgerQueryTerm is a single term of a query in a subset of the simple_query_string syntax:
+// "+term" must match, "-term" must not match and "term*" is a prefix query.
+type badgerQueryTerm struct {
+	term    string
+	prefix  bool
+	must    bool
+	mustNot bool
+}
+
+func parseBadgerQuery(query string) []badgerQueryTerm {
+	var result = []badgerQueryTerm{}
+	for _, word := range strings.Fields(query) {
+		qt := badgerQueryTerm{}
+		switch {
+		case strings.HasPrefix(word, "+"):
+			qt.must = true
+			word = word[1:]
+		case strings.HasPrefix(word, "-"):
+			qt.mustNot = true
+			word = word[1:]
+		}
+		if strings.HasSuffix(word, "*") {
+			qt.prefix = true
+			word = strings.TrimRight(word, "*")
+		}
+		terms := badgerTokenize(word)
+		for i, term := range terms {
+			t := qt
+			t.term = term
+			// only the last part of a split word is a prefix
+			t.prefix = qt.prefix && i == len(terms)-1
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// badgerSearchFields returns the fields and boosts per search type, analogous to ElasticResolver.Search
+func badgerSearchFields(searchType string) map[string]float64 {
+	switch searchType {
+	case "author":
+		return map[string]float64{"persons.name": 1}
+	case "estate", "collection":
+		return map[string]float64{"collectiontitle": 1}
+	case "title":
+		return map[string]float64{"title": 1}
+	case "fulltext":
+		return map[string]float64{"abstract": 1.1, "notes.title": 1.2, "notes.note": 1.0, "media.fulltext": 1.0}
+	case "signature":
+		return map[string]float64{"signature": 1}
+	default:
+		return map[string]float64{
+			"title":           4,
+			"persons.name":    4,
+			"collectiontitle": 2,
+			"series":          2,
+			"tags":            2,
+			"category":        1.5,
+			"abstract":        1.1,
+			"notes.title":     1.2,
+			"notes.note":      1.0,
+			"media.fulltext":  1.0,
+		}
+	}
+}
+
+// badgerPostings returns the term frequency per document id for a term in a field
+func badgerPostings(txn *badger.Txn, field string, qt badgerQueryTerm) (map[string]uint64, error) {
+	fieldPrefix := []byte(badgerPrefixFTS + field + "/")
+	prefix := append(bytes.Clone(fieldPrefix), []byte(qt.term)...)
+	if !qt.prefix {
+		prefix = append(prefix, 0)
+	}
+	var result = map[string]uint64{}
+	it := txn.NewIterator(badger.IteratorOptions{Prefix: prefix})
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		item := it.Item()
+		termID := item.Key()[len(fieldPrefix):]
+		pos := bytes.IndexByte(termID, 0)
+		if pos < 0 {
+			continue
+		}
+		id := string(termID[pos+1:])
+		if err := item.Value(func(val []byte) error {
+			tf, n := binary.Uvarint(val)
+			if n <= 0 {
+				return errors.Errorf("invalid term frequency in index key %q", item.Key())
+			}
+			result[id] += tf
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// BadgerSyncTimestamp returns the high-water mark of the record timestamps of the last sync.
+// it is zero if the database has never been synced
+func BadgerSyncTimestamp(txn *badger.Txn) (time.Time, error) {
+	var ts time.Time
+	item, err := txn.Get([]byte(badgerKeySyncTimestamp))
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return ts, nil
+		}
+		return ts, errors.Wrap(err, "cannot get sync timestamp")
+	}
+	if err := item.Value(func(val []byte) error {
+		return ts.UnmarshalText(val)
+	}); err != nil {
+		return ts, errors.Wrap(err, "cannot parse sync timestamp")
+	}
+	return ts, nil
+}
+
+// SetBadgerSyncTimestamp stores the high-water mark of the record timestamps
+func SetBadgerSyncTimestamp(txn *badger.Txn, ts time.Time) error {
+	data, err := ts.UTC().MarshalText()
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal sync timestamp")
+	}
+	if err := txn.Set([]byte(badgerKeySyncTimestamp), data); err != nil {
+		return errors.Wrap(err, "cannot write sync timestamp")
+	}
+	return nil
+}
+
+// BadgerDocCount returns the number of indexed records.
+// databases indexed before the counter was introduced are counted once
+func BadgerDocCount(txn *badger.Txn) (int, error) {
+	item, err := txn.Get([]byte(badgerKeyDocCount))
+	if err != nil {
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return len(BadgerIDs(txn)), nil
+		}
+		return 0, errors.Wrap(err, "cannot get document count")
+	}
+	var count int64
+	if err := item.Value(func(val []byte) error {
+		var n int
+		count, n = binary.Varint(val)
+		if n <= 0 {
+			return errors.Errorf("invalid document count %q", val)
+		}
+		return nil
+	}); err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return int(count), nil
+}
+
+func badgerAddDocCount(txn *badger.Txn, delta int) error {
+	count, err := BadgerDocCount(txn)
+	if err != nil {
+		return err
+	}
+	if err := txn.Set([]byte(badgerKeyDocCount), binary.AppendVarint(nil, int64(count+delta))); err != nil {
+		return errors.Wrap(err, "cannot write document count")
+	}
+	return nil
+}
+
+// BadgerRecordIDs returns the ids of all stored records, also of records without index
+func BadgerRecordIDs(txn *badger.Txn) []string {
+	var result = []string{}
+	it := txn.NewIterator(badger.IteratorOptions{})
+	defer it.Close()
+	// the index keys start with NUL and are sorted before the records
+	for it.Seek([]byte{1}); it.Valid(); it.Next() {
+		result = append(result, string(it.Item().KeyCopy(nil)))
+	}
+	return result
+}
+
+// BadgerIDs returns the ids of all indexed records
+func BadgerIDs(txn *badger.Txn) []string {
+	var result = []string{}
+	opts := badger.IteratorOptions{Prefix: []byte(badgerPrefixDoc)}
+	it := txn.NewIterator(opts)
+	defer it.Close()
+	for it.Rewind(); it.Valid(); it.Next() {
+		result = append(result, string(it.Item().Key()[len(badgerPrefixDoc):]))
+	}
+	return result
+}
+
+// badgerScore evaluates the query terms against the fulltext index and returns the score of every matching record.
+// only the candidates are scored, a nil set of candidates does not restrict the records.
+// an empty query matches all candidates with score 0
+func badgerScore(txn *badger.Txn, fields map[string]float64, terms []badgerQueryTerm, candidates badgerIDSet) (map[string]float64, error) {
+	count, err := BadgerDocCount(txn)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	numDocs := float64(count)
+
+	var scores = map[string]float64{}
+	var should = 0
+	var mustCount = map[string]int{}
+	var must = 0
+	var excluded = map[string]bool{}
+	for _, qt := range terms {
+		matched := map[string]bool{}
+		for field, boost := range fields {
+			postings, err := badgerPostings(txn, field, qt)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot read postings of %s:%s", field, qt.term)
+			}
+			if len(postings) == 0 {
+				continue
+			}
+			idf := math.Log(1 + numDocs/float64(len(postings)))
+			for id, tf := range postings {
+				if !candidates.contains(id) {
+					continue
+				}
+				matched[id] = true
+				if !qt.mustNot {
+					scores[id] += boost * (1 + math.Log(float64(tf))) * idf
+				}
+			}
+		}
+		switch {
+		case qt.mustNot:
+			for id := range matched {
+				excluded[id] = true
+			}
+		case qt.must:
+			must++
+			for id := range matched {
+				mustCount[id]++
+			}
+		default:
+			should++
+		}
+	}
+	if should == 0 && must == 0 {
+		// nothing positive to match: start with all candidates
+		ids := slices.Collect(maps.Keys(candidates))
+		if candidates == nil {
+			ids = BadgerIDs(txn)
+		}
+		for _, id := range ids {
+			if _, ok := scores[id]; !ok {
+				scores[id] = 0
+			}
+		}
+	}
+	for id := range scores {
+		if excluded[id] || mustCount[id] < must {
+			delete(scores, id)
+		}
+	}
+	return scores, nil
+}
@@ -2,28 +2,43 @@ package resolver
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
 
 	"emperror.dev/errors"
 	"github.com/andybalholm/brotli"
 	"github.com/dgraph-io/badger/v4"
+	"github.com/je4/revcat/v2/config"
 	"github.com/je4/revcat/v2/pkg/sourcetype"
 	"github.com/je4/revcat/v2/tools/graph/model"
 	"github.com/je4/utils/v2/pkg/zLogger"
 )
 
-func NewBadgerResolver(logger zLogger.ZLogger, db *badger.DB) Resolver {
-	return &badgerResolver{
-		logger: logger,
-		db:     db,
+func NewBadgerResolver(logger zLogger.ZLogger, db *badger.DB, cursorKey []byte, thema *Thema, clients []*config.Client) Resolver {
+	b := &badgerResolver{
+		logger:    logger,
+		db:        db,
+		cursorKey: cursorKey,
+		thema:     thema,
+		client:    make(map[string]*config.Client),
 	}
+	for _, client := range clients {
+		b.client[client.Name] = client
+	}
+	return b
 }
 
 type badgerResolver struct {
-	logger zLogger.ZLogger
-	db     *badger.DB
+	logger    zLogger.ZLogger
+	db        *badger.DB
+	cursorKey []byte
+	thema     *Thema
+	client    map[string]*config.Client
 }
 
 func (b *badgerResolver) loadEntries(ctx context.Context, signatures []string) ([]sourcetype.SourceData, error) {
@@ -50,7 +65,6 @@ func (b *badgerResolver) loadEntries(ctx context.Context, signatures []string) (
 			}); err != nil {
 				return err
 			}
-			return nil
 		}
 		return nil
 	}); err != nil {
@@ -59,11 +73,235 @@ func (b *badgerResolver) loadEntries(ctx context.Context, signatures []string) (
 	return result, nil
 }
 
-func (b *badgerResolver) Search(ctx context.Context, searchType string, query string, facets []*model.InFacet, filter []*model.InFilter, vector []float64, first *int, size *int, cursor *string, sort []*model.SortField) (*model.SearchResult, error) {
-	return nil, errors.Errorf("badgerResolver::Search not implemented")
+type badgerHit struct {
+	id    string
+	score float64
+	info  *badgerDocInfo
+}
+
+func badgerHitCompare(sort []*model.SortField) (func(a, b *badgerHit) int, error) {
+	var cmps = []func(a, b *badgerHit) int{}
+	for _, s := range sort {
+		field := strings.TrimSuffix(s.Field, ".keyword")
+		desc := strings.ToLower(s.Order) == "desc"
+		var c func(a, b *badgerHit) int
+		switch field {
+		case "_score":
+			c = func(a, b *badgerHit) int { return cmp.Compare(a.score, b.score) }
+		case "signature", "title", "date", "datestart", "dateend", "collectiontitle", "series", "dateadded", "timestamp":
+			c = func(a, b *badgerHit) int { return strings.Compare(a.info.Sort[field], b.info.Sort[field]) }
+		default:
+			return nil, errors.Errorf("sort field '%s' not supported by local index", s.Field)
+		}
+		if desc {
+			asc := c
+			c = func(a, b *badgerHit) int { return -asc(a, b) }
+		}
+		cmps = append(cmps, c)
+	}
+	if len(cmps) == 0 {
+		cmps = append(cmps, func(a, b *badgerHit) int { return cmp.Compare(b.score, a.score) })
+	}
+	return func(a, b *badgerHit) int {
+		for _, c := range cmps {
+			if r := c(a, b); r != 0 {
+				return r
+			}
+		}
+		return strings.Compare(a.info.Sort["signature"], b.info.Sort["signature"])
+	}, nil
 }
 
-func (b *badgerResolver) sourceToMediathekFullEntry(src *sourcetype.SourceData) *model.MediathekFullEntry {
+func (b *badgerResolver) Search(ctx context.Context, searchType string, query string, advancedQuery *model.InAdvancedQuery, facets []*model.InFacet, filter []*model.InFilter, vector []float64, vectorOptions *model.InVectorOptions, first *int, size *int, cursor *string, sort []*model.SortField, autoCorrect bool, explain bool, collapse *model.InCollapse) (*model.SearchResult, error) {
+	if errValue := ctx.Value("error"); errValue != nil {
+		return nil, errors.Errorf("%s", errValue)
+	}
+	if explain {
+		return nil, errors.Errorf("explain not supported by local index")
+	}
+	if collapse != nil {
+		return nil, errors.Errorf("collapse not supported by local index")
+	}
+	if len(vector) > 0 || searchType == "semantic" {
+		return nil, errors.Errorf("vector search not supported by local index")
+	}
+	if advancedQuery != nil {
+		return nil, errors.Errorf("advanced query not supported by local index")
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	clientName, err := stringFromContext(ctx, "client")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get client from context")
+	}
+	hash, err := queryHash(clientName, searchType, query, advancedQuery, facets, filter, vector, vectorOptions, sort)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create query hash")
+	}
+	var from = 0
+	var num = defaultPageSize
+
+	if first != nil {
+		from = *first
+	}
+	if size != nil {
+		num = *size
+	}
+	if cursor != nil && *cursor != "" {
+		crs, err := DecodeCursor(*cursor, b.cursorKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decode cursor '%s'", *cursor)
+		}
+		if crs.QueryHash != hash {
+			return nil, errors.Errorf("cursor '%s' does not belong to this search", *cursor)
+		}
+		from = crs.From
+		num = crs.Size
+	}
+	if from < 0 {
+		from = 0
+	}
+	if num < 0 {
+		num = defaultPageSize
+	}
+	num = clientPageSize(b.client[clientName], num)
+	hitCompare, err := badgerHitCompare(sort)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var hits = []*badgerHit{}
+	var facetResults = make([]*model.Facet, 0)
+	if err := b.db.View(func(txn *badger.Txn) error {
+		// same semantics as the elastic resolver: "and" and "exists" facet queries restrict
+		// the whole search, all other facet queries only act as post filter on the hits
+		clientIDs, err := badgerClientIDs(txn, b.client[clientName])
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		var filterSets = []badgerSet{clientIDs, badgerACLIDs(txn, groups)}
+		var postFilterSets = []badgerSet{}
+		for _, f := range filter {
+			ids, err := badgerFilterIDs(txn, f)
+			if err != nil {
+				return errors.Wrapf(err, "cannot evaluate filter %v", f)
+			}
+			filterSets = append(filterSets, ids)
+		}
+		var facetSets = make([]badgerSet, len(facets))
+		for i, f := range facets {
+			facetSets[i] = badgerIDSet(nil)
+			query := facetFilter(f)
+			if query == nil {
+				continue
+			}
+			ids, err := badgerFilterIDs(txn, query)
+			if err != nil {
+				return errors.Wrapf(err, "cannot evaluate facet filter %v", f)
+			}
+			facetSets[i] = ids
+			if (query.BoolTerm != nil && query.BoolTerm.And) || query.ExistsTerm != nil {
+				filterSets = append(filterSets, ids)
+			} else {
+				postFilterSets = append(postFilterSets, ids)
+			}
+		}
+		inAll := func(sets []badgerSet, id string) bool {
+			for _, set := range sets {
+				if !set.contains(id) {
+					return false
+				}
+			}
+			return true
+		}
+
+		// only the records passing the filters are scored
+		scores, err := badgerScore(txn, badgerSearchFields(searchType), parseBadgerQuery(query), badgerIntersect(txn, filterSets...))
+		if err != nil {
+			return errors.Wrapf(err, "cannot search for '%s'", query)
+		}
+
+		var baseIDs = []string{}
+		var infos = map[string]*badgerDocInfo{}
+		for id, score := range scores {
+			info, err := badgerLoadDocInfo(txn, id)
+			if err != nil {
+				return err
+			}
+			if access, _ := aclAccess(info.ACL, groups); !access["meta"] {
+				continue
+			}
+			baseIDs = append(baseIDs, id)
+			infos[id] = info
+			if inAll(postFilterSets, id) {
+				hits = append(hits, &badgerHit{id: id, score: score, info: info})
+			}
+		}
+
+		// every facet is counted with the queries of all other facets applied
+		for _, f := range facets {
+			if f.DateHistogram != nil || f.Range != nil || f.Hierarchy != nil {
+				return errors.Errorf("facet %s not supported by local index", facetName(f))
+			}
+			if f.Term == nil {
+				continue
+			}
+			var facetIDs = []string{}
+			for _, id := range baseIDs {
+				found := true
+				for i, f2 := range facets {
+					if f2.Term != nil && f2.Term.Name == f.Term.Name {
+						continue
+					}
+					if !facetSets[i].contains(id) {
+						found = false
+						break
+					}
+				}
+				if found {
+					facetIDs = append(facetIDs, id)
+				}
+			}
+			facet, err := badgerFacet(txn, f.Term, infos, facetIDs)
+			if err != nil {
+				return errors.Wrapf(err, "cannot create facet %s", f.Term.Name)
+			}
+			facetResults = append(facetResults, facet)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(hits, hitCompare)
+
+	var result = &model.SearchResult{
+		TotalCount: len(hits),
+		Edges:      make([]*model.MediathekFullEntry, 0),
+		Facets:     facetResults,
+	}
+	if result.PageInfo, err = newPageInfo(result.TotalCount, from, num, b.cursorKey, hash); err != nil {
+		return nil, errors.Wrap(err, "cannot create page info")
+	}
+	var signatures = []string{}
+	for _, hit := range hits[min(from, len(hits)):min(from+num, len(hits))] {
+		signatures = append(signatures, hit.id)
+	}
+	docs, err := b.loadEntries(ctx, signatures)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load entries %v", signatures)
+	}
+	for _, doc := range docs {
+		access, mediaProtected := aclAccess(doc.ACL, groups)
+		entry := b.sourceToMediathekFullEntry(&doc, access["content"], mediaProtected)
+		result.Edges = append(result.Edges, entry)
+	}
+	b.thema.labelResult(result)
+	return result, nil
+}
+
+func (b *badgerResolver) sourceToMediathekFullEntry(src *sourcetype.SourceData, mediaVisible, mediaProtected bool) *model.MediathekFullEntry {
 	entry := &model.MediathekFullEntry{
 		ID:             src.ID,
 		Base:           sourceToMediathekBaseEntry(src),
@@ -92,17 +330,18 @@ func (b *badgerResolver) sourceToMediathekFullEntry(src *sourcetype.SourceData)
 			entry.ReferencesFull = append(entry.ReferencesFull, sourceToMediathekBaseEntry(&ref))
 		}
 	}
-	for _, lang := range src.Abstract.GetNativeLanguages() {
+	abstract := src.GetAbstract()
+	for _, lang := range abstract.GetNativeLanguages() {
 		entry.Abstract = append(entry.Abstract, &model.MultiLangString{
 			Lang:       lang.String(),
-			Value:      src.Abstract.Get(lang).ContentString(),
+			Value:      abstract.Get(lang).ContentString(),
 			Translated: false,
 		})
 	}
-	for _, lang := range src.Abstract.GetTranslatedLanguages() {
+	for _, lang := range abstract.GetTranslatedLanguages() {
 		entry.Abstract = append(entry.Abstract, &model.MultiLangString{
 			Lang:       lang.String(),
-			Value:      src.Abstract.Get(lang).ContentString(),
+			Value:      abstract.Get(lang).ContentString(),
 			Translated: true,
 		})
 	}
@@ -120,29 +359,27 @@ func (b *badgerResolver) sourceToMediathekFullEntry(src *sourcetype.SourceData)
 			})
 		}
 	}
-	if src.Media != nil {
-		for key, ml := range src.Media {
-			mediaList := &model.MediaList{
-				Type:  key,
-				Items: make([]*model.Media, 0),
-			}
-			for _, media := range ml {
-				mediaList.Items = append(mediaList.Items, sourceMediaToMedia(&media))
-			}
-			entry.Media = append(entry.Media, mediaList)
-		}
-	}
+	entry.Base.MediaVisible = mediaVisible
+	entry.Base.MediaProtected = mediaProtected
+	entry.Media = sourceMediaToMediaList(src.ID, src.Media, mediaVisible)
 	return entry
 }
 
 func (b *badgerResolver) MediathekEntries(ctx context.Context, signatures []string) ([]*model.MediathekFullEntry, error) {
 	var result = []*model.MediathekFullEntry{}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
 	docs, err := b.loadEntries(ctx, signatures)
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot load entries %v", signatures)
 	}
 	for _, doc := range docs {
-		result = append(result, b.sourceToMediathekFullEntry(&doc))
+		access, mediaProtected := aclAccess(doc.ACL, groups)
+		entry := b.sourceToMediathekFullEntry(&doc, access["content"], mediaProtected)
+		b.thema.labelEntry(entry.Base)
+		result = append(result, entry)
 	}
 	return result, nil
 }
@@ -151,20 +388,325 @@ func (b *badgerResolver) VectorSearch(ctx context.Context, vector []float64, fac
 	return nil, errors.Errorf("badgerResolver::VectorSearch not implemented")
 }
 
+// ReferencesFull returns the entries referenced by obj and the entries referencing obj
 func (b *badgerResolver) ReferencesFull(ctx context.Context, obj *model.MediathekFullEntry) ([]*model.MediathekBaseEntry, error) {
+	if errValue := ctx.Value("error"); errValue != nil {
+		return nil, errors.Errorf("%s", errValue)
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	var signatures = []string{}
+	if err := b.db.View(func(txn *badger.Txn) error {
+		var candidates = []string{}
+		referencing := badgerTermIDs(txn, "references.signature", obj.ID, true)
+		for id := range referencing {
+			candidates = append(candidates, id)
+		}
+		slices.Sort(candidates)
+
+		info, err := badgerLoadDocInfo(txn, obj.ID)
+		if err != nil && !errors.Is(err, badger.ErrKeyNotFound) {
+			return err
+		}
+		if info != nil {
+			candidates = append(candidates, info.Terms["references.signature"]...)
+		}
+		for _, extra := range obj.Extra {
+			if extra.Key != "references" {
+				continue
+			}
+			for _, ref := range strings.Split(extra.Value, ";") {
+				refParts := strings.Split(ref, ":")
+				if len(refParts) != 2 {
+					b.logger.Error().Msgf("invalid reference '%s' for object %s", ref, obj.ID)
+					continue
+				}
+				if refParts[0] == "signature" {
+					candidates = append(candidates, refParts[1])
+				}
+			}
+		}
+
+		for _, id := range candidates {
+			if id == obj.ID || slices.Contains(signatures, id) {
+				continue
+			}
+			refInfo, err := badgerLoadDocInfo(txn, id)
+			if err != nil {
+				if errors.Is(err, badger.ErrKeyNotFound) {
+					b.logger.Debug().Msgf("reference %s of %s not in local index", id, obj.ID)
+					continue
+				}
+				return err
+			}
+			if access, _ := aclAccess(refInfo.ACL, groups); !access["meta"] {
+				continue
+			}
+			signatures = append(signatures, id)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "cannot find references of %s", obj.ID)
+	}
+
 	var result = []*model.MediathekBaseEntry{}
+	docs, err := b.loadEntries(ctx, signatures)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load entries %v", signatures)
+	}
+	for _, doc := range docs {
+		entry := sourceToMediathekBaseEntry(&doc)
+		b.thema.labelEntry(entry)
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// ThemaTree counts the documents per node of the thema taxonomy, which are visible to the client
+func (b *badgerResolver) ThemaTree(ctx context.Context, filter []*model.InFilter) ([]*model.ThemaNode, error) {
+	if errValue := ctx.Value("error"); errValue != nil {
+		return nil, errors.Errorf("%s", errValue)
+	}
+	if b.thema == nil {
+		return nil, errors.New("no thema taxonomy configured")
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	clientName, err := stringFromContext(ctx, "client")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get client from context")
+	}
+	field, _, err := badgerTermField(b.thema.field)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid thema field")
+	}
+	var counts = map[string]int{}
+	if err := b.db.View(func(txn *badger.Txn) error {
+		clientIDs, err := badgerClientIDs(txn, b.client[clientName])
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		var filterSets = []badgerSet{clientIDs}
+		for _, f := range filter {
+			ids, err := badgerFilterIDs(txn, f)
+			if err != nil {
+				return errors.Wrapf(err, "cannot evaluate filter %v", f)
+			}
+			filterSets = append(filterSets, ids)
+		}
+		var valueIDs = map[string][]string{}
+		badgerTermValues(txn, field, func(value, id string) {
+			valueIDs[value] = append(valueIDs[value], id)
+		})
+		var visible = map[string]bool{}
+		isVisible := func(id string) (bool, error) {
+			if v, ok := visible[id]; ok {
+				return v, nil
+			}
+			for _, set := range filterSets {
+				if !set.contains(id) {
+					visible[id] = false
+					return false, nil
+				}
+			}
+			info, err := badgerLoadDocInfo(txn, id)
+			if err != nil {
+				return false, err
+			}
+			access, _ := aclAccess(info.ACL, groups)
+			visible[id] = access["meta"]
+			return visible[id], nil
+		}
+		for node, values := range b.thema.nodeValues() {
+			var ids = map[string]bool{}
+			for _, value := range values {
+				for _, id := range valueIDs[value] {
+					ok, err := isVisible(id)
+					if err != nil {
+						return errors.Wrapf(err, "cannot load %s", id)
+					}
+					if ok {
+						ids[id] = true
+					}
+				}
+			}
+			counts[node] = len(ids)
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "cannot count thema categories")
+	}
+	return b.thema.nodes(counts), nil
+}
+
+func (b *badgerResolver) SearchRequest(ctx context.Context, searchType string, query string, advancedQuery *model.InAdvancedQuery, facets []*model.InFacet, filter []*model.InFilter, vector []float64, vectorOptions *model.InVectorOptions, first *int, size *int, cursor *string, sort []*model.SortField, collapse *model.InCollapse) (string, error) {
+	return "", errors.Errorf("search request not supported by local index")
+}
+
+func (b *badgerResolver) Autocomplete(ctx context.Context, prefix string, fields []string, size int) ([]*model.AutocompleteSuggestion, error) {
+	return nil, errors.Errorf("autocomplete not supported by local index")
+}
+
+// Related returns the entries sharing the most tags, categories and collections with the entry of the signature
+func (b *badgerResolver) Related(ctx context.Context, signature string, size int) ([]*model.MediathekBaseEntry, error) {
+	if errValue := ctx.Value("error"); errValue != nil {
+		return nil, errors.Errorf("%s", errValue)
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	clientName, err := stringFromContext(ctx, "client")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get client from context")
+	}
+	size = clientPageSize(b.client[clientName], size)
+	var hits = []*badgerHit{}
+	if err := b.db.View(func(txn *badger.Txn) error {
+		info, err := badgerLoadDocInfo(txn, signature)
+		if err != nil {
+			return errors.Wrapf(err, "cannot load %s", signature)
+		}
+		if access, _ := aclAccess(info.ACL, groups); !access["meta"] {
+			return errors.Errorf("entry %s not found", signature)
+		}
+		clientIDs, err := badgerClientIDs(txn, b.client[clientName])
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		// the source entry has to pass the base filter of the client like the hits of a search
+		if !clientIDs.contains(signature) {
+			return errors.Errorf("entry %s not found", signature)
+		}
+		exclude := append([]string{signature}, info.Terms["references.signature"]...)
+		var scores = map[string]float64{}
+		for _, field := range []string{"tags", "category", "collectiontitle"} {
+			for _, value := range info.Terms[field] {
+				for id := range badgerTermIDs(txn, field, value, true) {
+					scores[id]++
+				}
+			}
+		}
+		for id, score := range scores {
+			if slices.Contains(exclude, id) || !clientIDs.contains(id) {
+				continue
+			}
+			hitInfo, err := badgerLoadDocInfo(txn, id)
+			if err != nil {
+				return errors.Wrapf(err, "cannot load %s", id)
+			}
+			if access, _ := aclAccess(hitInfo.ACL, groups); !access["meta"] {
+				continue
+			}
+			hits = append(hits, &badgerHit{id: id, score: score, info: hitInfo})
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "cannot find entries related to %s", signature)
+	}
+	hitCompare, err := badgerHitCompare(nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	slices.SortFunc(hits, hitCompare)
 	var signatures = []string{}
-	for _, ref := range obj.Base.References {
-		signatures = append(signatures, ref.Signature)
+	for _, hit := range hits[:min(max(size, 0), len(hits))] {
+		signatures = append(signatures, hit.id)
 	}
 	docs, err := b.loadEntries(ctx, signatures)
 	if err != nil {
 		return nil, errors.Wrapf(err, "cannot load entries %v", signatures)
 	}
+	var result = []*model.MediathekBaseEntry{}
 	for _, doc := range docs {
-		result = append(result, sourceToMediathekBaseEntry(&doc))
+		access, mediaProtected := aclAccess(doc.ACL, groups)
+		entry := sourceToMediathekBaseEntry(&doc)
+		entry.MediaVisible = access["content"]
+		entry.MediaProtected = mediaProtected
+		b.thema.labelEntry(entry)
+		result = append(result, entry)
 	}
 	return result, nil
 }
 
+// the local index has no highlighter, the snippets of the fulltext matches are created with these sizes
+const (
+	badgerFragmentSize = 150
+	badgerFragments    = 3
+)
+
+// SearchInEntry returns the media items of the entry with all terms of the query in their fulltext
+func (b *badgerResolver) SearchInEntry(ctx context.Context, signature string, query string, size int) ([]*model.MediaMatch, error) {
+	if errValue := ctx.Value("error"); errValue != nil {
+		return nil, errors.Errorf("%s", errValue)
+	}
+	if size <= 0 {
+		return nil, errors.Errorf("invalid size %d", size)
+	}
+	terms := badgerTokenize(query)
+	if len(terms) == 0 {
+		return []*model.MediaMatch{}, nil
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	clientName, err := stringFromContext(ctx, "client")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get client from context")
+	}
+	docs, err := b.loadEntries(ctx, []string{signature})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load entry %s", signature)
+	}
+	src := &docs[0]
+	access, _ := aclAccess(src.ACL, groups)
+	if !access["meta"] {
+		return nil, errors.Errorf("entry %s not found", signature)
+	}
+	if !access["content"] {
+		return nil, errors.Errorf("no access to the media of entry %s", signature)
+	}
+	var result = []*model.MediaMatch{}
+	for _, mediaType := range fulltextMediaTypes(src) {
+		for index, media := range src.Media[mediaType] {
+			var positions = []fulltextRange{}
+			for _, term := range terms {
+				termPositions := fulltextPositions(media.Fulltext, []string{term})
+				if len(termPositions) == 0 {
+					positions = nil
+					break
+				}
+				positions = append(positions, termPositions...)
+			}
+			if len(positions) == 0 {
+				continue
+			}
+			slices.SortFunc(positions, func(a, b fulltextRange) int { return cmp.Compare(a.start, b.start) })
+			snippets := fulltextSnippets(media.Fulltext, positions, badgerFragmentSize, badgerFragments)
+			result = append(result, newMediaMatch(mediaType, index, sourceMediaToMedia(&media), new(float64(len(positions))), snippets, positions))
+		}
+	}
+	sortMediaMatches(result)
+	num := clientPageSize(b.client[clientName], size)
+	return result[:min(num, len(result))], nil
+}
+
+// MediaFulltext returns a part of the fulltext of the media
+func (b *badgerResolver) MediaFulltext(ctx context.Context, obj *model.Media, offset int, length *int) (*string, error) {
+	return fulltextPage(obj.Fulltext, offset, length)
+}
+
+// MediaFulltextLength returns the number of characters of the fulltext of the media
+func (b *badgerResolver) MediaFulltextLength(ctx context.Context, obj *model.Media) (int, error) {
+	if obj.Fulltext == nil {
+		return 0, nil
+	}
+	return utf8.RuneCountInString(*obj.Fulltext), nil
+}
+
 var _ Resolver = (*badgerResolver)(nil)
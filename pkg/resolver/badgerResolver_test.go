@@ -0,0 +1,495 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/je4/revcat/v2/config"
+	"github.com/je4/revcat/v2/pkg/sourcetype"
+	"github.com/je4/revcat/v2/tools/graph/model"
+	"github.com/rs/zerolog"
+	"go.ub.unibas.ch/metastring/pkg/metaString"
+	"go.ub.unibas.ch/metastring/pkg/multilangString"
+)
+
+func badgerTestSources() []*sourcetype.SourceData {
+	return []*sourcetype.SourceData{
+		{
+			ID:              "zotero2-1.A",
+			Signature:       "zotero2-1.A",
+			Title:           multilangString.NewMultiLangString(metaString.NewMetaString("Oceanic Issues")),
+			CollectionTitle: "Doce en Diciembre",
+			Persons:         []sourcetype.Person{{Name: "Mihaylova, Albena"}},
+			Tags:            []string{"performance", "ocean"},
+			Category:        []string{"zotero2!!Performance Art"},
+			Type:            "video",
+			References:      []sourcetype.Reference{{Type: "signature", Signature: "zotero2-2.B"}},
+			Media: map[string]sourcetype.MediaList{"pdf": {
+				{Name: "programm.pdf", Fulltext: "Programm des Abends"},
+				{Name: "partitur.pdf", Fulltext: "Ein Walzer und noch ein walzer, dann Pause"},
+			}},
+			ACL: map[string][]string{"meta": {"global/guest"}, "content": {"global/guest"}},
+		},
+		{
+			ID:              "zotero2-2.B",
+			Signature:       "zotero2-2.B",
+			Title:           multilangString.NewMultiLangString(metaString.NewMetaString("Motet Cycles")),
+			CollectionTitle: "Hochschule für Musik",
+			Persons:         []sourcetype.Person{{Name: "Ocean, Billy"}},
+			Tags:            []string{"music"},
+			Category:        []string{"zotero2!!Werke!!Hochschule für Musik!!Motet Cycles"},
+			Type:            "audio",
+			Notes:           []sourcetype.Note{{Title: "Programm", Note: "<p>Performance im Konzertsaal</p>"}},
+			Media:           map[string]sourcetype.MediaList{"pdf": {{Name: "score.pdf", Fulltext: "Walzer"}}},
+			ACL:             map[string][]string{"meta": {"global/guest"}, "content": {"global/admin"}},
+		},
+		{
+			ID:         "zotero2-3.C",
+			Signature:  "zotero2-3.C",
+			Title:      multilangString.NewMultiLangString(metaString.NewMetaString("Hidden Ocean")),
+			Tags:       []string{"ocean"},
+			Type:       "image",
+			References: []sourcetype.Reference{{Type: "signature", Signature: "zotero2-1.A"}},
+			ACL:        map[string][]string{"meta": {"global/admin"}},
+		},
+	}
+}
+
+func newBadgerTestResolver(t *testing.T) (*badgerResolver, *badger.DB) {
+	t.Helper()
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Fatalf("cannot open badger: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	for _, src := range badgerTestSources() {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("cannot marshal %s: %v", src.ID, err)
+		}
+		buf := bytes.NewBuffer(nil)
+		bw := brotli.NewWriter(buf)
+		if _, err := bw.Write(data); err != nil {
+			t.Fatalf("cannot compress %s: %v", src.ID, err)
+		}
+		if err := bw.Close(); err != nil {
+			t.Fatalf("cannot compress %s: %v", src.ID, err)
+		}
+		if err := db.Update(func(txn *badger.Txn) error {
+			if err := txn.Set([]byte(src.ID), buf.Bytes()); err != nil {
+				return err
+			}
+			return WriteBadgerIndex(txn, src.ID, src)
+		}); err != nil {
+			t.Fatalf("cannot store %s: %v", src.ID, err)
+		}
+	}
+	logger := zerolog.Nop()
+	clients := []*config.Client{
+		{Name: "limited", MaxPageSize: 1},
+		{Name: "music", AND: []config.ClientANDQuery{{OR: []config.ClientOrQuery{{Field: "tags.keyword", Values: []string{"music"}}, {Field: "type", Values: []string{"image"}}}}}},
+		{Name: "unindexed", AND: []config.ClientANDQuery{{OR: []config.ClientOrQuery{{Field: "publisher.keyword", Values: []string{"Hochschule"}}}}}},
+	}
+	return NewBadgerResolver(&logger, db, testCursorKey, nil, clients).(*badgerResolver), db
+}
+
+func guestContext() context.Context {
+	return context.WithValue(context.Background(), "groups", []string{"global/guest"})
+}
+
+func edgeIDs(result *model.SearchResult) []string {
+	var ids = []string{}
+	for _, edge := range result.Edges {
+		ids = append(ids, edge.ID)
+	}
+	return ids
+}
+
+func TestBadgerResolver_Search(t *testing.T) {
+	r, _ := newBadgerTestResolver(t)
+	tests := []struct {
+		name       string
+		searchType string
+		query      string
+		want       []string
+	}{
+		{name: "match all", searchType: "all", query: "", want: []string{"zotero2-1.A", "zotero2-2.B"}},
+		{name: "title and person", searchType: "all", query: "ocean", want: []string{"zotero2-1.A", "zotero2-2.B"}},
+		{name: "author only", searchType: "author", query: "ocean", want: []string{"zotero2-2.B"}},
+		{name: "title only", searchType: "title", query: "motet", want: []string{"zotero2-2.B"}},
+		{name: "prefix", searchType: "title", query: "ocea*", want: []string{"zotero2-1.A"}},
+		{name: "notes without markup", searchType: "fulltext", query: "konzertsaal", want: []string{"zotero2-2.B"}},
+		{name: "must not", searchType: "all", query: "performance -music", want: []string{"zotero2-1.A"}},
+		{name: "signature", searchType: "signature", query: "+zotero2-2.B", want: []string{"zotero2-2.B"}},
+		{name: "no hit", searchType: "all", query: "nothing", want: []string{}},
+	}
+	sort := []*model.SortField{{Field: "signature.keyword", Order: "asc"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := r.Search(guestContext(), tt.searchType, tt.query, nil, nil, nil, nil, nil, nil, nil, nil, sort, false, false, nil)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			got := edgeIDs(result)
+			if len(got) != len(tt.want) || result.TotalCount != len(tt.want) {
+				t.Fatalf("Search() = %v (total %d), want %v", got, result.TotalCount, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Search() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestBadgerResolver_SearchPaging(t *testing.T) {
+	r, _ := newBadgerTestResolver(t)
+	size := 1
+	sort := []*model.SortField{{Field: "signature", Order: "desc"}}
+	result, err := r.Search(guestContext(), "all", "", nil, nil, nil, nil, nil, nil, &size, nil, sort, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if result.TotalCount != 2 || len(result.Edges) != 1 || result.Edges[0].ID != "zotero2-2.B" {
+		t.Fatalf("Search() = %v (total %d), want [zotero2-2.B] (total 2)", edgeIDs(result), result.TotalCount)
+	}
+	if !result.PageInfo.HasNextPage || result.PageInfo.HasPreviousPage {
+		t.Errorf("PageInfo = %+v, want next page only", result.PageInfo)
+	}
+	if result.Edges[0].Base.MediaVisible || !result.Edges[0].Base.MediaProtected {
+		t.Errorf("media of %s should be protected and not visible", result.Edges[0].ID)
+	}
+
+	current, err := r.Search(guestContext(), "all", "", nil, nil, nil, nil, nil, nil, nil, &result.PageInfo.CurrentCursor, sort, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() with cursor error = %v", err)
+	}
+	if got := edgeIDs(current); len(got) != 1 || got[0] != "zotero2-2.B" {
+		t.Errorf("Search() with cursor = %v, want [zotero2-2.B]", got)
+	}
+
+	next, err := r.Search(guestContext(), "all", "", nil, nil, nil, nil, nil, nil, nil, &result.PageInfo.EndCursor, sort, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() with end cursor error = %v", err)
+	}
+	if got := edgeIDs(next); len(got) != 1 || got[0] != "zotero2-1.A" {
+		t.Errorf("Search() with end cursor = %v, want [zotero2-1.A]", got)
+	}
+	if next.PageInfo.HasNextPage || !next.PageInfo.HasPreviousPage {
+		t.Errorf("PageInfo = %+v, want previous page only", next.PageInfo)
+	}
+	prev, err := r.Search(guestContext(), "all", "", nil, nil, nil, nil, nil, nil, nil, &next.PageInfo.StartCursor, sort, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() with start cursor error = %v", err)
+	}
+	if got := edgeIDs(prev); len(got) != 1 || got[0] != "zotero2-2.B" {
+		t.Errorf("Search() with start cursor = %v, want [zotero2-2.B]", got)
+	}
+
+	// a cursor is bound to the query and the client it was created for
+	if _, err := r.Search(guestContext(), "all", "ocean", nil, nil, nil, nil, nil, nil, nil, &result.PageInfo.CurrentCursor, sort, false, false, nil); err == nil {
+		t.Errorf("Search() with cursor of another query should fail")
+	}
+	otherClient := context.WithValue(guestContext(), "client", "limited")
+	if _, err := r.Search(otherClient, "all", "", nil, nil, nil, nil, nil, nil, nil, &result.PageInfo.CurrentCursor, sort, false, false, nil); err == nil {
+		t.Errorf("Search() with cursor of another client should fail")
+	}
+}
+
+func TestBadgerResolver_SearchMaxPageSize(t *testing.T) {
+	r, _ := newBadgerTestResolver(t)
+	size := 100
+	ctx := context.WithValue(guestContext(), "client", "limited")
+	result, err := r.Search(ctx, "all", "", nil, nil, nil, nil, nil, nil, &size, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if result.TotalCount != 2 || len(result.Edges) != 1 || !result.PageInfo.HasNextPage {
+		t.Errorf("Search() = %v (total %d), want one entry per page", edgeIDs(result), result.TotalCount)
+	}
+}
+
+func TestBadgerResolver_SearchClientFilter(t *testing.T) {
+	r, _ := newBadgerTestResolver(t)
+	admin := context.WithValue(context.Background(), "groups", []string{"global/guest", "global/admin"})
+	music := context.WithValue(admin, "client", "music")
+	result, err := r.Search(music, "all", "", nil, nil, nil, nil, nil, nil, nil, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	// zotero2-1.A is neither tagged "music" nor an image and is not visible to the client
+	if ids := edgeIDs(result); result.TotalCount != 2 || !reflect.DeepEqual(ids, []string{"zotero2-2.B", "zotero2-3.C"}) {
+		t.Errorf("Search() = %v (total %d), want zotero2-2.B and zotero2-3.C", ids, result.TotalCount)
+	}
+	if _, err := r.Related(music, "zotero2-1.A", 10); err == nil {
+		t.Errorf("Related() of entry not visible to the client should fail")
+	}
+	related, err := r.Related(music, "zotero2-3.C", 10)
+	if err != nil {
+		t.Fatalf("Related() error = %v", err)
+	}
+	for _, entry := range related {
+		if entry.Signature == "zotero2-1.A" {
+			t.Errorf("Related() = %+v, contains entry not visible to the client", related)
+		}
+	}
+	unindexed := context.WithValue(admin, "client", "unindexed")
+	if _, err := r.Search(unindexed, "all", "", nil, nil, nil, nil, nil, nil, nil, nil, nil, false, false, nil); err == nil {
+		t.Errorf("Search() of client filtering on a field not in the local index should fail")
+	}
+}
+
+func TestBadgerResolver_Reindex(t *testing.T) {
+	r, db := newBadgerTestResolver(t)
+	src := badgerTestSources()[0]
+	src.Title = multilangString.NewMultiLangString(metaString.NewMetaString("Renamed"))
+	if err := db.Update(func(txn *badger.Txn) error {
+		return WriteBadgerIndex(txn, src.ID, src)
+	}); err != nil {
+		t.Fatalf("cannot reindex %s: %v", src.ID, err)
+	}
+	result, err := r.Search(guestContext(), "title", "oceanic", nil, nil, nil, nil, nil, nil, nil, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if result.TotalCount != 0 {
+		t.Errorf("old title still indexed: %v", edgeIDs(result))
+	}
+	if err := db.View(func(txn *badger.Txn) error {
+		count, err := BadgerDocCount(txn)
+		if err != nil {
+			return err
+		}
+		if count != len(badgerTestSources()) {
+			t.Errorf("BadgerDocCount() = %d after reindexing, want %d", count, len(badgerTestSources()))
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("cannot count documents: %v", err)
+	}
+}
+
+func TestBadgerRecordIDs(t *testing.T) {
+	_, db := newBadgerTestResolver(t)
+	// records of databases built before the index have no index info
+	if err := db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte("zotero2-4.D"), []byte{})
+	}); err != nil {
+		t.Fatalf("cannot store record: %v", err)
+	}
+	if err := db.View(func(txn *badger.Txn) error {
+		if ids := BadgerRecordIDs(txn); !reflect.DeepEqual(ids, []string{"zotero2-1.A", "zotero2-2.B", "zotero2-3.C", "zotero2-4.D"}) {
+			t.Errorf("BadgerRecordIDs() = %v", ids)
+		}
+		if ids := BadgerIDs(txn); len(ids) != 3 {
+			t.Errorf("BadgerIDs() = %v, want the indexed records", ids)
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("cannot read ids: %v", err)
+	}
+}
+
+func facetCounts(facet *model.Facet) map[string]int {
+	var counts = map[string]int{}
+	for _, v := range facet.Values {
+		if fv, ok := v.(*model.FacetValueString); ok {
+			counts[fv.StrVal] = fv.Count
+		}
+	}
+	return counts
+}
+
+func TestBadgerResolver_SearchFacets(t *testing.T) {
+	r, _ := newBadgerTestResolver(t)
+	facets := []*model.InFacet{
+		{
+			Term: &model.InFacetTerm{Field: "type.keyword", Name: "type", MinDocCount: 1, Size: 10},
+			Query: &model.InFilter{BoolTerm: &model.InFilterBoolTerm{
+				Field:  "type.keyword",
+				Values: []string{"video"},
+			}},
+		},
+		{
+			Term:  &model.InFacetTerm{Field: "tags.keyword", Name: "tags", MinDocCount: 1, Size: 10},
+			Query: &model.InFilter{},
+		},
+	}
+	result, err := r.Search(guestContext(), "all", "", nil, facets, nil, nil, nil, nil, nil, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := edgeIDs(result); len(got) != 1 || got[0] != "zotero2-1.A" {
+		t.Fatalf("Search() = %v, want [zotero2-1.A]", got)
+	}
+	if len(result.Facets) != 2 {
+		t.Fatalf("got %d facets, want 2", len(result.Facets))
+	}
+	// the post filter of a facet does not restrict its own buckets
+	typeCounts := facetCounts(result.Facets[0])
+	if len(typeCounts) != 2 || typeCounts["video"] != 1 || typeCounts["audio"] != 1 {
+		t.Errorf("type facet = %v, want video:1 audio:1", typeCounts)
+	}
+	tagCounts := facetCounts(result.Facets[1])
+	if len(tagCounts) != 2 || tagCounts["ocean"] != 1 || tagCounts["performance"] != 1 {
+		t.Errorf("tags facet = %v, want ocean:1 performance:1", tagCounts)
+	}
+
+	filter := []*model.InFilter{{BoolTerm: &model.InFilterBoolTerm{
+		Field:  "category",
+		Values: []string{"werke"},
+	}}}
+	result, err = r.Search(guestContext(), "all", "", nil, nil, filter, nil, nil, nil, nil, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := edgeIDs(result); len(got) != 1 || got[0] != "zotero2-2.B" {
+		t.Errorf("Search() with category match = %v, want [zotero2-2.B]", got)
+	}
+
+	filter = []*model.InFilter{{BoolTerm: &model.InFilterBoolTerm{
+		Field:  "[persons].name.keyword",
+		Values: []string{"Ocean, Billy"},
+	}}}
+	if _, err := r.Search(guestContext(), "all", "", nil, nil, filter, nil, nil, nil, nil, nil, nil, false, false, nil); err == nil {
+		t.Errorf("Search() with unsupported nested filter should fail")
+	}
+
+	filter = []*model.InFilter{{NotTerm: &model.InFilter{PrefixTerm: &model.InFilterPrefixTerm{
+		Field:  "category.keyword",
+		Values: []string{"zotero2!!Werke"},
+	}}}}
+	result, err = r.Search(guestContext(), "all", "", nil, nil, filter, nil, nil, nil, nil, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := edgeIDs(result); len(got) != 1 || got[0] != "zotero2-1.A" {
+		t.Errorf("Search() with not prefix filter = %v, want [zotero2-1.A]", got)
+	}
+
+	// a prefix on the text field matches the tokens, on the keyword field the whole value
+	filter = []*model.InFilter{{PrefixTerm: &model.InFilterPrefixTerm{
+		Field:  "collectiontitle",
+		Values: []string{"musi"},
+	}}}
+	result, err = r.Search(guestContext(), "all", "", nil, nil, filter, nil, nil, nil, nil, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := edgeIDs(result); len(got) != 1 || got[0] != "zotero2-2.B" {
+		t.Errorf("Search() with text prefix filter = %v, want [zotero2-2.B]", got)
+	}
+	filter[0].PrefixTerm.Field = "collectiontitle.keyword"
+	result, err = r.Search(guestContext(), "all", "", nil, nil, filter, nil, nil, nil, nil, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := edgeIDs(result); len(got) != 0 {
+		t.Errorf("Search() with keyword prefix filter = %v, want none", got)
+	}
+
+	filter = []*model.InFilter{{WildcardTerm: &model.InFilterWildcardTerm{
+		Field:           "collectiontitle.keyword",
+		CaseInsensitive: true,
+		Values:          []string{"hochschule*"},
+	}}}
+	result, err = r.Search(guestContext(), "all", "", nil, nil, filter, nil, nil, nil, nil, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if got := edgeIDs(result); len(got) != 1 || got[0] != "zotero2-2.B" {
+		t.Errorf("Search() with wildcard filter = %v, want [zotero2-2.B]", got)
+	}
+
+	filter = []*model.InFilter{{}}
+	if _, err := r.Search(guestContext(), "all", "", nil, nil, filter, nil, nil, nil, nil, nil, nil, false, false, nil); err == nil {
+		t.Errorf("Search() with empty filter should fail")
+	}
+}
+
+func TestBadgerResolver_ReferencesFull(t *testing.T) {
+	r, _ := newBadgerTestResolver(t)
+	tests := []struct {
+		name   string
+		id     string
+		groups []string
+		want   []string
+	}{
+		{name: "referenced", id: "zotero2-1.A", groups: []string{"global/guest"}, want: []string{"zotero2-2.B"}},
+		{name: "referencing", id: "zotero2-2.B", groups: []string{"global/guest"}, want: []string{"zotero2-1.A"}},
+		{name: "both directions", id: "zotero2-1.A", groups: []string{"global/guest", "global/admin"}, want: []string{"zotero2-3.C", "zotero2-2.B"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.WithValue(context.Background(), "groups", tt.groups)
+			refs, err := r.ReferencesFull(ctx, &model.MediathekFullEntry{ID: tt.id})
+			if err != nil {
+				t.Fatalf("ReferencesFull() error = %v", err)
+			}
+			var got = []string{}
+			for _, ref := range refs {
+				got = append(got, ref.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReferencesFull() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBadgerResolver_Related(t *testing.T) {
+	r, _ := newBadgerTestResolver(t)
+	admin := context.WithValue(context.Background(), "groups", []string{"global/guest", "global/admin"})
+	related, err := r.Related(admin, "zotero2-1.A", 10)
+	if err != nil {
+		t.Fatalf("Related() error = %v", err)
+	}
+	// zotero2-2.B is referenced by zotero2-1.A and not related
+	if len(related) != 1 || related[0].Signature != "zotero2-3.C" {
+		t.Errorf("Related() = %+v, want zotero2-3.C", related)
+	}
+	if related, err = r.Related(guestContext(), "zotero2-1.A", 10); err != nil || len(related) != 0 {
+		t.Errorf("Related() for guest = %+v, %v, want no entry", related, err)
+	}
+	if _, err := r.Related(guestContext(), "zotero2-3.C", 10); err == nil {
+		t.Errorf("Related() of hidden entry should fail")
+	}
+}
+
+func TestBadgerResolver_SearchInEntry(t *testing.T) {
+	r, _ := newBadgerTestResolver(t)
+	matches, err := r.SearchInEntry(guestContext(), "zotero2-1.A", "walzer", 10)
+	if err != nil {
+		t.Fatalf("SearchInEntry() error = %v", err)
+	}
+	if len(matches) != 1 || matches[0].Type != "pdf" || matches[0].Index != 1 || !matches[0].Media.Matched {
+		t.Fatalf("SearchInEntry() = %+v", matches)
+	}
+	if len(matches[0].Positions) != 2 || matches[0].Positions[0] != 4 || matches[0].Positions[1] != 24 {
+		t.Errorf("SearchInEntry() positions = %v, want [4 24]", matches[0].Positions)
+	}
+	if len(matches[0].Snippets) != 1 || matches[0].Snippets[0] != "Ein <em>Walzer</em> und noch ein <em>walzer</em>, dann Pause" {
+		t.Errorf("SearchInEntry() snippets = %v", matches[0].Snippets)
+	}
+	if matches, err := r.SearchInEntry(guestContext(), "zotero2-1.A", "walzer programm", 10); err != nil || len(matches) != 0 {
+		t.Errorf("SearchInEntry() with terms in different media = %v, %v, want no match", matches, err)
+	}
+
+	// the content of zotero2-2.B is only visible to admins
+	if _, err := r.SearchInEntry(guestContext(), "zotero2-2.B", "walzer", 10); err == nil {
+		t.Errorf("SearchInEntry() without content access should fail")
+	}
+	entries, err := r.MediathekEntries(guestContext(), []string{"zotero2-2.B"})
+	if err != nil {
+		t.Fatalf("MediathekEntries() error = %v", err)
+	}
+	if fulltext := entries[0].Media[0].Items[0].Fulltext; fulltext != nil {
+		t.Errorf("MediathekEntries() without content access returns fulltext %q", *fulltext)
+	}
+}
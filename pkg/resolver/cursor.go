@@ -1,11 +1,22 @@
 package resolver
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
 	emperrors "emperror.dev/errors"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/tools/graph/model"
 )
 
+// defaultPageSize is the page size of searches without size
+const defaultPageSize = 36
+
 func NewCursor(from, size int) *cursor {
 	return &cursor{
 		From: from,
@@ -13,27 +24,157 @@ func NewCursor(from, size int) *cursor {
 	}
 }
 
-func DecodeCursor(s string) (*cursor, error) {
-	cCursor, err := base64.StdEncoding.DecodeString(s)
+// DecodeCursor verifies the signature of a cursor created by Encode and decodes it
+func DecodeCursor(s string, key []byte) (*cursor, error) {
+	payload, signature, ok := strings.Cut(s, ".")
+	if !ok {
+		return nil, emperrors.New("cursor not signed")
+	}
+	cCursor, err := base64.StdEncoding.DecodeString(payload)
 	if err != nil {
 		return nil, emperrors.Wrap(err, "cannot decode cursor")
 	}
+	mac, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return nil, emperrors.Wrap(err, "cannot decode cursor signature")
+	}
+	if !hmac.Equal(mac, cursorMAC(cCursor, key)) {
+		return nil, emperrors.New("invalid cursor signature")
+	}
 	c := &cursor{}
-	if err := json.Unmarshal(cCursor, c); err != nil {
+	// keep the sort values as they are, long values would lose precision as float64
+	dec := json.NewDecoder(bytes.NewReader(cCursor))
+	dec.UseNumber()
+	if err := dec.Decode(c); err != nil {
 		return nil, emperrors.Wrap(err, "cannot unmarshal cursor")
 	}
 	return c, nil
 }
 
+// cursor addresses a result page.
+// From is the offset of the page. If SearchAfter is set, the page starts after the hit with
+// these sort values (before it, if Before is set) and From is only used for the page info.
+// PIT is the point in time id the cursor was created with.
+// QueryHash binds the cursor to the search it was created for.
+// Corrected is the query searched instead of the query of the client, if the search was auto corrected.
 type cursor struct {
-	From int `json:"from"`
-	Size int `json:"size"`
+	From        int                `json:"from"`
+	Size        int                `json:"size"`
+	SearchAfter []types.FieldValue `json:"searchAfter,omitempty"`
+	Before      bool               `json:"before,omitempty"`
+	PIT         string             `json:"pit,omitempty"`
+	QueryHash   string             `json:"query"`
+	Corrected   string             `json:"corrected,omitempty"`
 }
 
-func (c *cursor) Encode() (string, error) {
+// Encode marshals the cursor and signs it with the server key
+func (c *cursor) Encode(key []byte) (string, error) {
 	jCursor, err := json.Marshal(c)
 	if err != nil {
 		return "", emperrors.Wrap(err, "cannot marshal cursor")
 	}
-	return base64.StdEncoding.EncodeToString(jCursor), nil
+	return base64.StdEncoding.EncodeToString(jCursor) + "." + base64.StdEncoding.EncodeToString(cursorMAC(jCursor, key)), nil
+}
+
+func cursorMAC(data, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// queryHash identifies a search of a client by all its parameters, which influence the result list
+func queryHash(clientName string, params ...any) (string, error) {
+	data, err := json.Marshal(append([]any{clientName}, params...))
+	if err != nil {
+		return "", emperrors.Wrap(err, "cannot marshal query parameters")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// newPageInfo creates the paging cursors for a result page starting at from with num entries.
+// The next page starts right after the page, the previous page ends right before it.
+func newPageInfo(totalCount, from, num int, key []byte, hash string) (*model.PageInfo, error) {
+	var err error
+	pageInfo := &model.PageInfo{}
+	encode := func(from int) (string, error) {
+		c := NewCursor(from, num)
+		c.QueryHash = hash
+		return c.Encode(key)
+	}
+	if totalCount > from+num {
+		pageInfo.HasNextPage = true
+		if pageInfo.EndCursor, err = encode(from + num); err != nil {
+			return nil, emperrors.Wrap(err, "cannot marshal end cursor")
+		}
+	}
+	if from > 0 {
+		pageInfo.HasPreviousPage = true
+		if pageInfo.StartCursor, err = encode(max(from-num, 0)); err != nil {
+			return nil, emperrors.Wrap(err, "cannot marshal start cursor")
+		}
+	}
+	if pageInfo.CurrentCursor, err = encode(from); err != nil {
+		return nil, emperrors.Wrap(err, "cannot marshal current cursor")
+	}
+	return pageInfo, nil
+}
+
+// newSearchAfterPageInfo creates the paging cursors for a result page, which continue with
+// search_after from the sort values of the first and last hit of the page
+func newSearchAfterPageInfo(totalCount int, current *cursor, firstSort, lastSort []types.FieldValue, pit string, key []byte) (*model.PageInfo, error) {
+	var err error
+	from, num := current.From, current.Size
+	pageInfo := &model.PageInfo{}
+	if totalCount > from+num && len(lastSort) > 0 {
+		pageInfo.HasNextPage = true
+		next := &cursor{From: from + num, Size: num, SearchAfter: lastSort, PIT: pit, QueryHash: current.QueryHash, Corrected: current.Corrected}
+		if pageInfo.EndCursor, err = next.Encode(key); err != nil {
+			return nil, emperrors.Wrap(err, "cannot marshal end cursor")
+		}
+	}
+	if from > 0 {
+		pageInfo.HasPreviousPage = true
+		prev := &cursor{From: 0, Size: num, PIT: pit, QueryHash: current.QueryHash, Corrected: current.Corrected}
+		if from-num > 0 && len(firstSort) > 0 {
+			prev = &cursor{From: from - num, Size: num, SearchAfter: firstSort, Before: true, PIT: pit, QueryHash: current.QueryHash, Corrected: current.Corrected}
+		}
+		if pageInfo.StartCursor, err = prev.Encode(key); err != nil {
+			return nil, emperrors.Wrap(err, "cannot marshal start cursor")
+		}
+	}
+	currentCursor := *current
+	currentCursor.PIT = pit
+	if pageInfo.CurrentCursor, err = currentCursor.Encode(key); err != nil {
+		return nil, emperrors.Wrap(err, "cannot marshal current cursor")
+	}
+	return pageInfo, nil
+}
+
+// newOffsetPageInfo creates the paging cursors for a result page without sort values,
+// which is paged by offset within the point in time
+func newOffsetPageInfo(totalCount int, current *cursor, pit string, key []byte) (*model.PageInfo, error) {
+	var err error
+	from, num := current.From, current.Size
+	pageInfo := &model.PageInfo{}
+	if totalCount > from+num {
+		pageInfo.HasNextPage = true
+		next := &cursor{From: from + num, Size: num, PIT: pit, QueryHash: current.QueryHash, Corrected: current.Corrected}
+		if pageInfo.EndCursor, err = next.Encode(key); err != nil {
+			return nil, emperrors.Wrap(err, "cannot marshal end cursor")
+		}
+	}
+	if from > 0 {
+		pageInfo.HasPreviousPage = true
+		prev := &cursor{From: max(from-num, 0), Size: num, PIT: pit, QueryHash: current.QueryHash, Corrected: current.Corrected}
+		if pageInfo.StartCursor, err = prev.Encode(key); err != nil {
+			return nil, emperrors.Wrap(err, "cannot marshal start cursor")
+		}
+	}
+	currentCursor := *current
+	currentCursor.PIT = pit
+	if pageInfo.CurrentCursor, err = currentCursor.Encode(key); err != nil {
+		return nil, emperrors.Wrap(err, "cannot marshal current cursor")
+	}
+	return pageInfo, nil
 }
@@ -0,0 +1,127 @@
+package resolver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+var testCursorKey = []byte("test cursor key")
+
+func TestSearchAfterPageInfo(t *testing.T) {
+	current := &cursor{From: 72, Size: 36, SearchAfter: []types.FieldValue{json.Number("1.5"), "zotero2-1.A"}, QueryHash: "hash"}
+	firstSort := []types.FieldValue{json.Number("2.25"), "zotero2-1.B"}
+	lastSort := []types.FieldValue{json.Number("1709251200000"), "zotero2-9.Z"}
+	pageInfo, err := newSearchAfterPageInfo(200, current, firstSort, lastSort, "pit-id", testCursorKey)
+	if err != nil {
+		t.Fatalf("newSearchAfterPageInfo() error = %v", err)
+	}
+	if !pageInfo.HasNextPage || !pageInfo.HasPreviousPage {
+		t.Fatalf("PageInfo = %+v, want next and previous page", pageInfo)
+	}
+
+	next, err := DecodeCursor(pageInfo.EndCursor, testCursorKey)
+	if err != nil {
+		t.Fatalf("DecodeCursor(EndCursor) error = %v", err)
+	}
+	if next.From != 108 || next.Size != 36 || next.Before || next.PIT != "pit-id" || next.QueryHash != "hash" {
+		t.Errorf("next cursor = %+v", next)
+	}
+	// long sort values must not lose precision
+	if len(next.SearchAfter) != 2 || next.SearchAfter[0] != json.Number("1709251200000") || next.SearchAfter[1] != "zotero2-9.Z" {
+		t.Errorf("next search after = %v, want %v", next.SearchAfter, lastSort)
+	}
+
+	prev, err := DecodeCursor(pageInfo.StartCursor, testCursorKey)
+	if err != nil {
+		t.Fatalf("DecodeCursor(StartCursor) error = %v", err)
+	}
+	if prev.From != 36 || !prev.Before || len(prev.SearchAfter) != 2 || prev.SearchAfter[1] != "zotero2-1.B" {
+		t.Errorf("previous cursor = %+v", prev)
+	}
+
+	// the page before the second page is the first page, which needs no sort values
+	pageInfo, err = newSearchAfterPageInfo(200, &cursor{From: 36, Size: 36}, firstSort, lastSort, "", testCursorKey)
+	if err != nil {
+		t.Fatalf("newSearchAfterPageInfo() error = %v", err)
+	}
+	prev, err = DecodeCursor(pageInfo.StartCursor, testCursorKey)
+	if err != nil {
+		t.Fatalf("DecodeCursor(StartCursor) error = %v", err)
+	}
+	if prev.From != 0 || prev.Before || len(prev.SearchAfter) != 0 {
+		t.Errorf("first page cursor = %+v", prev)
+	}
+
+	// last page
+	pageInfo, err = newSearchAfterPageInfo(200, &cursor{From: 180, Size: 36}, firstSort, lastSort, "", testCursorKey)
+	if err != nil {
+		t.Fatalf("newSearchAfterPageInfo() error = %v", err)
+	}
+	if pageInfo.HasNextPage || pageInfo.EndCursor != "" {
+		t.Errorf("PageInfo = %+v, want no next page", pageInfo)
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	signed, err := (&cursor{From: 36, Size: 36, QueryHash: "hash"}).Encode(testCursorKey)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	crs, err := DecodeCursor(signed, testCursorKey)
+	if err != nil {
+		t.Fatalf("DecodeCursor() error = %v", err)
+	}
+	if crs.From != 36 || crs.Size != 36 || crs.QueryHash != "hash" {
+		t.Errorf("DecodeCursor() = %+v", crs)
+	}
+
+	payload, signature, _ := strings.Cut(signed, ".")
+	tampered, err := json.Marshal(&cursor{From: 36, Size: 10000, QueryHash: "hash"})
+	if err != nil {
+		t.Fatalf("cannot marshal cursor: %v", err)
+	}
+	tests := []struct {
+		name   string
+		cursor string
+		key    []byte
+	}{
+		{name: "modified", cursor: base64.StdEncoding.EncodeToString(tampered) + "." + signature, key: testCursorKey},
+		{name: "unsigned", cursor: payload, key: testCursorKey},
+		{name: "other key", cursor: signed, key: []byte("other key")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeCursor(tt.cursor, tt.key); err == nil {
+				t.Errorf("DecodeCursor() should fail")
+			}
+		})
+	}
+}
+
+func TestOffsetPageInfo(t *testing.T) {
+	pageInfo, err := newOffsetPageInfo(100, &cursor{From: 20, Size: 36, QueryHash: "hash"}, "pit-id", testCursorKey)
+	if err != nil {
+		t.Fatalf("newOffsetPageInfo() error = %v", err)
+	}
+	if !pageInfo.HasNextPage || !pageInfo.HasPreviousPage {
+		t.Fatalf("PageInfo = %+v, want next and previous page", pageInfo)
+	}
+	next, err := DecodeCursor(pageInfo.EndCursor, testCursorKey)
+	if err != nil {
+		t.Fatalf("DecodeCursor(EndCursor) error = %v", err)
+	}
+	if next.From != 56 || len(next.SearchAfter) != 0 || next.PIT != "pit-id" || next.QueryHash != "hash" {
+		t.Errorf("next cursor = %+v", next)
+	}
+	prev, err := DecodeCursor(pageInfo.StartCursor, testCursorKey)
+	if err != nil {
+		t.Fatalf("DecodeCursor(StartCursor) error = %v", err)
+	}
+	if prev.From != 0 || prev.Before {
+		t.Errorf("previous cursor = %+v", prev)
+	}
+}
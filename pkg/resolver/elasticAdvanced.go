@@ -0,0 +1,189 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/operator"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// advancedFields are the fields allowed in advanced queries with the field used for exact values and ranges.
+// Nested fields are written as [path].field, fields without keyword field only support contains, prefix and exists.
+// Ranges of date use the normalized interval of datestart and dateend instead of the free text.
+var advancedFields = map[string]string{
+	"title":           "title.keyword",
+	"[persons].name":  "[persons].name.keyword",
+	"[persons].year":  "[persons].year",
+	"collectiontitle": "collectiontitle.keyword",
+	"series":          "series.keyword",
+	"tags":            "tags.keyword",
+	"category":        "category.keyword",
+	"abstract":        "",
+	"[notes].title":   "",
+	"[notes].note":    "",
+	"date":            "date.keyword",
+	"datestart":       "datestart",
+	"dateend":         "dateend",
+	"dateadded":       "dateadded",
+	"place":           "place.keyword",
+	"publisher":       "publisher.keyword",
+	"signature":       "signature.keyword",
+	"type":            "type.keyword",
+	"mediatype":       "mediatype.keyword",
+	"source":          "source.keyword",
+}
+
+// advancedMaxDepth limits the nesting of groups in advanced queries
+const advancedMaxDepth = 8
+
+// nestedFieldQuery creates the query of a field in [path].field syntax, fields of nested documents are queried with a nested query
+func nestedFieldQuery(field string, query func(fieldName string) types.Query) types.Query {
+	matches := nestedRegexp.FindStringSubmatch(field)
+	if len(matches) != 3 {
+		return query(field)
+	}
+	return types.Query{Nested: &types.NestedQuery{
+		Path:  matches[1],
+		Query: query(fmt.Sprintf("%s.%s", matches[1], matches[2])),
+	}}
+}
+
+// createAdvancedClause translates a field clause of an advanced query
+func createAdvancedClause(clause *model.InAdvancedClause) (*types.Query, error) {
+	keywordField, ok := advancedFields[clause.Field]
+	if !ok {
+		return nil, errors.WithStack(newValidationError("field '%s' not allowed in advanced query", clause.Field))
+	}
+	var value string
+	if clause.Value != nil {
+		value = *clause.Value
+	}
+	needValue := func() error {
+		if strings.TrimSpace(value) == "" {
+			return newValidationError("no value for operator '%s' on field '%s'", clause.Operator, clause.Field)
+		}
+		return nil
+	}
+	needKeyword := func() error {
+		if keywordField == "" {
+			return newValidationError("operator '%s' not supported on field '%s'", clause.Operator, clause.Field)
+		}
+		return nil
+	}
+	switch strings.ToLower(clause.Operator) {
+	case "equals":
+		if err := needKeyword(); err != nil {
+			return nil, err
+		}
+		if err := needValue(); err != nil {
+			return nil, err
+		}
+		return createFilterQuery(&model.InFilter{BoolTerm: &model.InFilterBoolTerm{
+			Field:  keywordField,
+			And:    true,
+			Values: []string{value},
+		}})
+	case "contains":
+		if err := needValue(); err != nil {
+			return nil, err
+		}
+		return new(nestedFieldQuery(clause.Field, func(fieldName string) types.Query {
+			return types.Query{Match: map[string]types.MatchQuery{
+				fieldName: {Query: value, Operator: &operator.And},
+			}}
+		})), nil
+	case "prefix":
+		if err := needValue(); err != nil {
+			return nil, err
+		}
+		return new(nestedFieldQuery(clause.Field, func(fieldName string) types.Query {
+			return types.Query{MatchPhrasePrefix: map[string]types.MatchPhrasePrefixQuery{
+				fieldName: {Query: value},
+			}}
+		})), nil
+	case "range":
+		if err := needKeyword(); err != nil {
+			return nil, err
+		}
+		if clause.From == nil && clause.To == nil {
+			return nil, errors.WithStack(newValidationError("no bounds for range on field '%s'", clause.Field))
+		}
+		if clause.Field == "date" {
+			return createDateRange(clause.From, clause.To)
+		}
+		return createFilterQuery(&model.InFilter{RangeTerm: &model.InFilterRangeTerm{
+			Field: keywordField,
+			From:  clause.From,
+			To:    clause.To,
+		}})
+	case "exists":
+		return new(nestedFieldQuery(clause.Field, func(fieldName string) types.Query {
+			return types.Query{Exists: &types.ExistsQuery{Field: fieldName}}
+		})), nil
+	default:
+		return nil, errors.WithStack(newValidationError("invalid operator '%s' on field '%s'", clause.Operator, clause.Field))
+	}
+}
+
+// createDateRange matches the entries, whose date interval overlaps the range from including to excluding
+func createDateRange(from, to *string) (*types.Query, error) {
+	var queries = []types.Query{}
+	if to != nil {
+		query, err := createFilterQuery(&model.InFilter{RangeTerm: &model.InFilterRangeTerm{Field: "datestart", To: to}})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		queries = append(queries, *query)
+	}
+	if from != nil {
+		query, err := createFilterQuery(&model.InFilter{RangeTerm: &model.InFilterRangeTerm{Field: "dateend", From: from}})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		queries = append(queries, *query)
+	}
+	return &types.Query{Bool: &types.BoolQuery{Filter: queries}}, nil
+}
+
+// createAdvancedQuery translates the tree of an advanced query.
+// The clauses and groups of a "not" group must all not match.
+func createAdvancedQuery(q *model.InAdvancedQuery) (*types.Query, error) {
+	return createAdvancedGroup(q, 0)
+}
+
+func createAdvancedGroup(q *model.InAdvancedQuery, depth int) (*types.Query, error) {
+	if depth >= advancedMaxDepth {
+		return nil, errors.WithStack(newValidationError("advanced query nested deeper than %d levels", advancedMaxDepth))
+	}
+	var queries = []types.Query{}
+	for _, clause := range q.Clauses {
+		query, err := createAdvancedClause(clause)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		queries = append(queries, *query)
+	}
+	for _, group := range q.Groups {
+		query, err := createAdvancedGroup(group, depth+1)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		queries = append(queries, *query)
+	}
+	if len(queries) == 0 {
+		return nil, errors.WithStack(newValidationError("empty group in advanced query"))
+	}
+	switch strings.ToLower(q.Operator) {
+	case "and":
+		return &types.Query{Bool: &types.BoolQuery{Must: queries}}, nil
+	case "or":
+		return &types.Query{Bool: &types.BoolQuery{Should: queries, MinimumShouldMatch: 1}}, nil
+	case "not":
+		return &types.Query{Bool: &types.BoolQuery{MustNot: queries}}, nil
+	default:
+		return nil, errors.WithStack(newValidationError("invalid group operator '%s' in advanced query", q.Operator))
+	}
+}
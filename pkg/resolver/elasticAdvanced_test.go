@@ -0,0 +1,96 @@
+package resolver
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/je4/revcat/v2/tools/graph/model"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestCreateAdvancedQuery(t *testing.T) {
+	// person = X AND added 2020-2022 NOT category Y
+	q := &model.InAdvancedQuery{
+		Operator: "and",
+		Clauses: []*model.InAdvancedClause{
+			{Field: "[persons].name", Operator: "equals", Value: new("Mihaylova, Albena")},
+			{Field: "dateadded", Operator: "range", From: new("2020"), To: new("2023")},
+		},
+		Groups: []*model.InAdvancedQuery{{
+			Operator: "not",
+			Clauses:  []*model.InAdvancedClause{{Field: "category", Operator: "equals", Value: new("zotero2!!Konvolute")}},
+		}},
+	}
+	query, err := createAdvancedQuery(q)
+	if err != nil {
+		t.Fatalf("createAdvancedQuery() error = %v", err)
+	}
+	if query.Bool == nil || len(query.Bool.Must) != 3 || query.Bool.Must[2].Bool == nil || len(query.Bool.Must[2].Bool.MustNot) != 1 {
+		t.Fatalf("createAdvancedQuery() = %+v", query)
+	}
+	data, err := json.Marshal(query)
+	if err != nil {
+		t.Fatalf("cannot marshal query: %v", err)
+	}
+	for _, want := range []string{`"path":"persons"`, `"persons.name.keyword"`, `"dateadded":{"gte":"2020","lt":"2023"}`, `"category.keyword"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("createAdvancedQuery() = %s, does not contain %s", data, want)
+		}
+	}
+
+	// the range of the free text date overlaps the normalized interval
+	dateRange, err := createAdvancedQuery(&model.InAdvancedQuery{Operator: "and", Clauses: []*model.InAdvancedClause{
+		{Field: "date", Operator: "range", From: new("1990"), To: new("2000")},
+	}})
+	if err != nil {
+		t.Fatalf("createAdvancedQuery() error = %v", err)
+	}
+	if data, err = json.Marshal(dateRange); err != nil {
+		t.Fatalf("cannot marshal query: %v", err)
+	}
+	for _, want := range []string{`"datestart":{"lt":"2000"}`, `"dateend":{"gte":"1990"}`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("createAdvancedQuery() = %s, does not contain %s", data, want)
+		}
+	}
+
+	prefix, err := createAdvancedQuery(&model.InAdvancedQuery{Operator: "or", Clauses: []*model.InAdvancedClause{
+		{Field: "[notes].note", Operator: "prefix", Value: new("konz")},
+		{Field: "abstract", Operator: "exists"},
+	}})
+	if err != nil {
+		t.Fatalf("createAdvancedQuery() error = %v", err)
+	}
+	if len(prefix.Bool.Should) != 2 || prefix.Bool.Should[0].Nested == nil || prefix.Bool.Should[0].Nested.Query.MatchPhrasePrefix["notes.note"].Query != "konz" {
+		t.Errorf("createAdvancedQuery() = %+v", prefix.Bool.Should)
+	}
+
+	for name, invalid := range map[string]*model.InAdvancedQuery{
+		"unknown field":          {Operator: "and", Clauses: []*model.InAdvancedClause{{Field: "acl.meta", Operator: "equals", Value: new("global/admin")}}},
+		"equals without keyword": {Operator: "and", Clauses: []*model.InAdvancedClause{{Field: "abstract", Operator: "equals", Value: new("x")}}},
+		"missing value":          {Operator: "and", Clauses: []*model.InAdvancedClause{{Field: "title", Operator: "contains"}}},
+		"unknown operator":       {Operator: "and", Clauses: []*model.InAdvancedClause{{Field: "title", Operator: "like", Value: new("x")}}},
+		"range without bounds":   {Operator: "and", Clauses: []*model.InAdvancedClause{{Field: "dateadded", Operator: "range"}}},
+		"empty group":            {Operator: "and"},
+		"invalid group":          {Operator: "xor", Clauses: []*model.InAdvancedClause{{Field: "title", Operator: "exists"}}},
+	} {
+		_, err := createAdvancedQuery(invalid)
+		var gqlErr *gqlerror.Error
+		if !errors.As(err, &gqlErr) || gqlErr.Extensions["code"] != errcode.ValidationFailed {
+			t.Errorf("createAdvancedQuery() with %s error = %v, want validation error", name, err)
+		}
+	}
+
+	deep := &model.InAdvancedQuery{Operator: "and", Clauses: []*model.InAdvancedClause{{Field: "title", Operator: "exists"}}}
+	for range advancedMaxDepth {
+		deep = &model.InAdvancedQuery{Operator: "and", Groups: []*model.InAdvancedQuery{deep}}
+	}
+	_, err = createAdvancedQuery(deep)
+	var gqlErr *gqlerror.Error
+	if !errors.As(err, &gqlErr) || gqlErr.Extensions["code"] != errcode.ValidationFailed {
+		t.Errorf("createAdvancedQuery() nested too deep error = %v, want validation error", err)
+	}
+}
@@ -0,0 +1,130 @@
+package resolver
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// autocompleteFields maps the suggestion types to the text fields of the index, nested fields are written as [path].field.
+// The values are taken from the keyword sub field, so no special mapping is needed.
+var autocompleteFields = map[string]string{
+	"title":      "title",
+	"person":     "[persons].name",
+	"collection": "collectiontitle",
+	"tag":        "tags",
+}
+
+// autocompleteTypes returns the requested suggestion types, all types if none are requested
+func autocompleteTypes(fields []string) ([]string, error) {
+	if len(fields) == 0 {
+		fields = []string{"person", "title", "collection", "tag"}
+	}
+	var result = []string{}
+	for _, field := range fields {
+		if _, ok := autocompleteFields[field]; !ok {
+			return nil, errors.Errorf("invalid autocomplete field '%s'", field)
+		}
+		if !slices.Contains(result, field) {
+			result = append(result, field)
+		}
+	}
+	return result, nil
+}
+
+// luceneReserved are the characters with special meaning in lucene regular expressions
+const luceneReserved = `.?+*|{}[]()"\#@&<>~`
+
+// prefixRegexp creates a case insensitive lucene regular expression, which matches values with a word starting with prefix
+func prefixRegexp(prefix string) string {
+	var sb strings.Builder
+	sb.WriteString(`(.*[ (/\-])?`)
+	for _, r := range prefix {
+		switch {
+		case unicode.ToLower(r) != unicode.ToUpper(r):
+			fmt.Fprintf(&sb, "[%c%c]", unicode.ToLower(r), unicode.ToUpper(r))
+		case strings.ContainsRune(luceneReserved, r):
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteString(".*")
+	return sb.String()
+}
+
+// newAutocompleteRequest creates a search for the entries with a word starting with prefix in one of the fields.
+// The matching values are collected with a terms aggregation per suggestion type.
+func newAutocompleteRequest(prefix string, suggestTypes []string, size int, filter []types.Query) (*search.Request, error) {
+	var should = []types.Query{}
+	var aggs = map[string]types.Aggregations{}
+	for _, suggestType := range suggestTypes {
+		field := autocompleteFields[suggestType]
+		var query = types.Query{MatchPhrasePrefix: map[string]types.MatchPhrasePrefixQuery{}}
+		matches := nestedRegexp.FindStringSubmatch(field)
+		if len(matches) == 3 {
+			query.MatchPhrasePrefix[fmt.Sprintf("%s.%s", matches[1], matches[2])] = types.MatchPhrasePrefixQuery{Query: prefix}
+			query = types.Query{Nested: &types.NestedQuery{Path: matches[1], Query: query}}
+		} else {
+			query.MatchPhrasePrefix[field] = types.MatchPhrasePrefixQuery{Query: prefix}
+		}
+		should = append(should, query)
+		agg, err := createFacetAggregation(&model.InFacet{Term: &model.InFacetTerm{
+			Field:       field + ".keyword",
+			Name:        suggestType,
+			MinDocCount: 1,
+			Size:        size,
+			Include:     []string{prefixRegexp(prefix)},
+		}})
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot create aggregation for %s", suggestType)
+		}
+		aggs[suggestType] = *agg
+	}
+	return &search.Request{
+		Query: &types.Query{Bool: &types.BoolQuery{
+			Filter:             filter,
+			Should:             should,
+			MinimumShouldMatch: 1,
+		}},
+		Aggregations: aggs,
+	}, nil
+}
+
+// autocompleteSuggestions converts the aggregations to suggestions, which are ordered by type and count
+func autocompleteSuggestions(suggestTypes []string, aggs map[string]types.Aggregate) ([]*model.AutocompleteSuggestion, error) {
+	var result = []*model.AutocompleteSuggestion{}
+	for _, suggestType := range suggestTypes {
+		agg, ok := aggs[suggestType]
+		if !ok {
+			continue
+		}
+		values, err := facetValues(nil, agg)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read aggregation %s", suggestType)
+		}
+		var suggestions = []*model.AutocompleteSuggestion{}
+		for _, value := range values {
+			if strValue, ok := value.(*model.FacetValueString); ok {
+				suggestions = append(suggestions, &model.AutocompleteSuggestion{
+					Type:  suggestType,
+					Value: strValue.StrVal,
+					Count: strValue.Count,
+				})
+			}
+		}
+		slices.SortStableFunc(suggestions, func(a, b *model.AutocompleteSuggestion) int {
+			return cmp.Compare(b.Count, a.Count)
+		})
+		result = append(result, suggestions...)
+	}
+	return result, nil
+}
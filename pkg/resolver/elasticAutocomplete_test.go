@@ -0,0 +1,81 @@
+package resolver
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+)
+
+func TestPrefixRegexp(t *testing.T) {
+	// lucene and go agree on the syntax used here
+	re := regexp.MustCompile("^" + prefixRegexp("Oce") + "$")
+	for value, want := range map[string]bool{
+		"Oceanic Issues":       true,
+		"Mensajes oceánicos":   true,
+		"Issues (oceanic)":     true,
+		"Performance / OCEAN":  true,
+		"Pacific":              false,
+		"Doce en Diciembre":    false,
+		"Billy Ocean, Singer":  true,
+		"Voce":                 false,
+		"Oceanic-Ocean Issues": true,
+	} {
+		if got := re.MatchString(value); got != want {
+			t.Errorf("prefixRegexp() match %q = %v, want %v", value, got, want)
+		}
+	}
+	if got := prefixRegexp("a.b"); got != `(.*[ (/\-])?[aA]\.[bB].*` {
+		t.Errorf("prefixRegexp() = %s", got)
+	}
+}
+
+func TestAutocomplete(t *testing.T) {
+	if _, err := autocompleteTypes([]string{"signature"}); err == nil {
+		t.Errorf("autocompleteTypes() with invalid field should fail")
+	}
+	suggestTypes, err := autocompleteTypes(nil)
+	if err != nil || len(suggestTypes) != 4 {
+		t.Fatalf("autocompleteTypes() = %v, %v", suggestTypes, err)
+	}
+	request, err := newAutocompleteRequest("oce", suggestTypes, 5, nil)
+	if err != nil {
+		t.Fatalf("newAutocompleteRequest() error = %v", err)
+	}
+	person := request.Aggregations["person"]
+	if person.Nested == nil || person.Aggregations["theAggregation"].Terms == nil || *person.Aggregations["theAggregation"].Terms.Field != "persons.name.keyword" {
+		t.Errorf("newAutocompleteRequest() person aggregation = %+v", person)
+	}
+	if len(request.Query.Bool.Should) != 4 || request.Query.Bool.Should[0].Nested == nil {
+		t.Errorf("newAutocompleteRequest() query = %+v", request.Query.Bool)
+	}
+
+	resp := search.NewResponse()
+	if err := json.Unmarshal([]byte(`{
+		"hits": {"hits": []},
+		"aggregations": {
+			"nested#person": {"doc_count": 4, "sterms#theAggregation": {"buckets": [
+				{"key": "Ocean, Billy", "doc_count": 3, "reverse_nested#entries": {"doc_count": 2}}
+			]}},
+			"sterms#title": {"buckets": [
+				{"key": "Hidden Ocean", "doc_count": 1},
+				{"key": "Oceanic Issues", "doc_count": 2}
+			]},
+			"sterms#collection": {"buckets": []},
+			"sterms#tag": {"buckets": [{"key": "ocean", "doc_count": 5}]}
+		}
+	}`), resp); err != nil {
+		t.Fatalf("cannot unmarshal response: %v", err)
+	}
+	got, err := autocompleteSuggestions(suggestTypes, resp.Aggregations)
+	if err != nil {
+		t.Fatalf("autocompleteSuggestions() error = %v", err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("autocompleteSuggestions() = %d suggestions, want 4", len(got))
+	}
+	if got[0].Type != "person" || got[0].Count != 2 || got[1].Value != "Oceanic Issues" || got[3].Type != "tag" {
+		t.Errorf("autocompleteSuggestions() = %+v %+v %+v %+v", got[0], got[1], got[2], got[3])
+	}
+}
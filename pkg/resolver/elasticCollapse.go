@@ -0,0 +1,99 @@
+package resolver
+
+import (
+	"encoding/json"
+	"slices"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// collapseFields are the single valued keyword fields search results can be collapsed on
+var collapseFields = []string{
+	"collectiontitle.keyword",
+	"series.keyword",
+	"publisher.keyword",
+	"place.keyword",
+	"type.keyword",
+	"source.keyword",
+}
+
+const (
+	// collapseAggregation is the name of the aggregation, which counts the groups of a collapsed search
+	collapseAggregation = "_collapse"
+	// collapseInnerHits is the name of the inner hits of a group
+	collapseInnerHits = "collapse"
+	// collapseMaxInnerHits limits the number of hits returned per group
+	collapseMaxInnerHits = 20
+	// collapsePrecision is the number of groups, which are counted exactly
+	collapsePrecision = 40000
+	// collapseMissing is counted for documents without value, it differs from every stored value including the empty one
+	collapseMissing = "\x00"
+)
+
+// newCollapse creates the collapse of the search on the field with the inner hits of every group sorted like the result.
+// The inner hits are always requested, they contain the number of hits of the group.
+// The groups are counted by a cardinality aggregation, which has to apply the post filter like the hits.
+// Documents without value in the field are collapsed into one group, they are counted as one more value.
+func newCollapse(collapse *model.InCollapse, sort []types.SortCombinations, postFilter *types.Query) (*types.FieldCollapse, *types.Aggregations, error) {
+	if !slices.Contains(collapseFields, collapse.Field) {
+		return nil, nil, errors.Errorf("field '%s' not allowed for collapse", collapse.Field)
+	}
+	if collapse.InnerHits < 0 || collapse.InnerHits > collapseMaxInnerHits {
+		return nil, nil, errors.Errorf("inner hits of collapse must be between 0 and %d", collapseMaxInnerHits)
+	}
+	fieldCollapse := &types.FieldCollapse{
+		Field: collapse.Field,
+		InnerHits: []types.InnerHits{{
+			Name:    new(collapseInnerHits),
+			Size:    new(collapse.InnerHits),
+			Sort:    sort,
+			Source_: types.SourceFilter{Excludes: searchSourceExcludes},
+		}},
+	}
+	filter := postFilter
+	if filter == nil {
+		filter = &types.Query{MatchAll: types.NewMatchAllQuery()}
+	}
+	agg := &types.Aggregations{
+		Filter: filter,
+		Aggregations: map[string]types.Aggregations{
+			"theAggregation": {Cardinality: &types.CardinalityAggregation{
+				Field:              new(collapse.Field),
+				Missing:            collapseMissing,
+				PrecisionThreshold: new(collapsePrecision),
+			}},
+		},
+	}
+	return fieldCollapse, agg, nil
+}
+
+// collapseCount returns the number of groups of a collapsed search
+func collapseCount(aggs map[string]types.Aggregate) (int, error) {
+	filterAgg, ok := aggs[collapseAggregation].(*types.FilterAggregate)
+	if !ok {
+		return 0, errors.Errorf("collapse aggregation not found")
+	}
+	cardinality, ok := filterAgg.Aggregations["theAggregation"].(*types.CardinalityAggregate)
+	if !ok {
+		return 0, errors.Errorf("unknown collapse aggregation type %T", filterAgg.Aggregations["theAggregation"])
+	}
+	return int(cardinality.Value), nil
+}
+
+// collapseValue returns the value of the collapse field of a hit
+func collapseValue(hit *types.Hit, field string) (string, error) {
+	data, ok := hit.Fields[field]
+	if !ok {
+		return "", nil
+	}
+	var values = []*string{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return "", errors.Wrapf(err, "cannot unmarshal value of collapse field '%s'", field)
+	}
+	if len(values) == 0 || values[0] == nil {
+		return "", nil
+	}
+	return *values[0], nil
+}
@@ -0,0 +1,44 @@
+package resolver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+func TestCollapseCount(t *testing.T) {
+	resp := search.NewResponse()
+	if err := json.Unmarshal([]byte(`{"aggregations": {
+		"filter#_collapse": {"doc_count": 42, "cardinality#theAggregation": {"value": 7}}
+	}}`), resp); err != nil {
+		t.Fatalf("cannot unmarshal aggregations: %v", err)
+	}
+	count, err := collapseCount(resp.Aggregations)
+	if err != nil {
+		t.Fatalf("collapseCount() error = %v", err)
+	}
+	if count != 7 {
+		t.Errorf("collapseCount() = %d, want 7", count)
+	}
+	if _, err := collapseCount(map[string]types.Aggregate{}); err == nil {
+		t.Error("collapseCount() without aggregation should fail")
+	}
+}
+
+func TestCollapseValue(t *testing.T) {
+	hit := &types.Hit{Fields: map[string]json.RawMessage{
+		"series.keyword": json.RawMessage(`["Perf en Bref"]`),
+	}}
+	if value, err := collapseValue(hit, "series.keyword"); err != nil || value != "Perf en Bref" {
+		t.Errorf("collapseValue() = %q, %v, want 'Perf en Bref'", value, err)
+	}
+	if value, err := collapseValue(hit, "collectiontitle.keyword"); err != nil || value != "" {
+		t.Errorf("collapseValue() of missing field = %q, %v, want empty value", value, err)
+	}
+	hit.Fields["series.keyword"] = json.RawMessage(`[null]`)
+	if value, err := collapseValue(hit, "series.keyword"); err != nil || value != "" {
+		t.Errorf("collapseValue() of null = %q, %v, want empty value", value, err)
+	}
+}
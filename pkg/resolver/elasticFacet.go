@@ -0,0 +1,350 @@
+package resolver
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/calendarinterval"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// facetName returns the name of the facet, which is the name of its aggregation
+func facetName(f *model.InFacet) string {
+	switch {
+	case f.Term != nil:
+		return f.Term.Name
+	case f.DateHistogram != nil:
+		return f.DateHistogram.Name
+	case f.Range != nil:
+		return f.Range.Name
+	case f.Hierarchy != nil:
+		return f.Hierarchy.Name
+	default:
+		return ""
+	}
+}
+
+// facetField returns the field of the facet
+func facetField(f *model.InFacet) string {
+	switch {
+	case f.Term != nil:
+		return f.Term.Field
+	case f.DateHistogram != nil:
+		return f.DateHistogram.Field
+	case f.Range != nil:
+		return f.Range.Field
+	case f.Hierarchy != nil:
+		return f.Hierarchy.Field
+	default:
+		return ""
+	}
+}
+
+// calendarIntervals are the calendar intervals of a date histogram facet and the length of a bucket
+var calendarIntervals = map[string]func(t time.Time) time.Time{
+	"minute":  func(t time.Time) time.Time { return t.Add(time.Minute) },
+	"hour":    func(t time.Time) time.Time { return t.Add(time.Hour) },
+	"day":     func(t time.Time) time.Time { return t.AddDate(0, 0, 1) },
+	"week":    func(t time.Time) time.Time { return t.AddDate(0, 0, 7) },
+	"month":   func(t time.Time) time.Time { return t.AddDate(0, 1, 0) },
+	"quarter": func(t time.Time) time.Time { return t.AddDate(0, 3, 0) },
+	"year":    func(t time.Time) time.Time { return t.AddDate(1, 0, 0) },
+}
+
+// hierarchyScript emits every node of the paths, a document is counted once per node
+const hierarchyScript = `def result = [];
+for (def value : doc[params.field]) {
+  if (params.prefix != '' && value != params.prefix && !value.startsWith(params.prefix + params.separator)) {
+    continue;
+  }
+  int pos = value.indexOf(params.separator, params.prefix.length());
+  while (pos >= 0) {
+    result.add(value.substring(0, pos));
+    pos = value.indexOf(params.separator, pos + params.separator.length());
+  }
+  result.add(value);
+}
+return result;`
+
+// createFacetAggregation creates the aggregation of a facet, facets without aggregation return nil.
+// Facets on a nested field like "[persons].name.keyword" are aggregated within the nested documents
+// and count the top level entries of each bucket with a reverse_nested aggregation.
+func createFacetAggregation(f *model.InFacet) (*types.Aggregations, error) {
+	field := facetField(f)
+	matches := nestedRegexp.FindStringSubmatch(field)
+	if len(matches) == 3 {
+		field = fmt.Sprintf("%s.%s", matches[1], matches[2])
+	}
+	agg, err := createFieldAggregation(f, field)
+	if err != nil || agg == nil || len(matches) != 3 {
+		return agg, err
+	}
+	agg.Aggregations = map[string]types.Aggregations{
+		"entries": {ReverseNested: &types.ReverseNestedAggregation{}},
+	}
+	if agg.Terms != nil {
+		agg.Terms.Order = map[string]sortorder.SortOrder{"entries": sortorder.Desc}
+	}
+	return &types.Aggregations{
+		Nested: &types.NestedAggregation{Path: &matches[1]},
+		Aggregations: map[string]types.Aggregations{
+			"theAggregation": *agg,
+		},
+	}, nil
+}
+
+func createFieldAggregation(f *model.InFacet, field string) (*types.Aggregations, error) {
+	switch {
+	case f.Term != nil:
+		termAgg := &types.TermsAggregation{
+			Field:       &field,
+			Size:        &f.Term.Size,
+			MinDocCount: &f.Term.MinDocCount,
+			//				Exclude:     []string{"bangbang!!.*"},
+			//Include: aggInclude,
+			//			Name:  &f.Name,
+		}
+		if len(f.Term.Include) == 1 {
+			termAgg.Include = f.Term.Include[0]
+		} else {
+			if len(f.Term.Include) > 1 {
+				termAgg.Include = f.Term.Include
+				termAgg.Size = new(len(f.Term.Include))
+				termAgg.MinDocCount = new(0)
+			}
+		}
+		return &types.Aggregations{Terms: termAgg}, nil
+	case f.DateHistogram != nil:
+		if _, ok := calendarIntervals[f.DateHistogram.CalendarInterval]; !ok {
+			return nil, errors.Errorf("invalid calendar interval '%s' of facet %s", f.DateHistogram.CalendarInterval, f.DateHistogram.Name)
+		}
+		return &types.Aggregations{DateHistogram: &types.DateHistogramAggregation{
+			Field:            &field,
+			CalendarInterval: &calendarinterval.CalendarInterval{Name: f.DateHistogram.CalendarInterval},
+			Format:           f.DateHistogram.Format,
+			MinDocCount:      &f.DateHistogram.MinDocCount,
+		}}, nil
+	case f.Range != nil:
+		if len(f.Range.Ranges) == 0 {
+			return nil, errors.Errorf("no ranges in facet %s", f.Range.Name)
+		}
+		if f.Range.Date {
+			dateAgg := &types.DateRangeAggregation{Field: &field}
+			for _, r := range f.Range.Ranges {
+				expr := types.DateRangeExpression{Key: r.Key}
+				if r.From != nil {
+					expr.From = *r.From
+				}
+				if r.To != nil {
+					expr.To = *r.To
+				}
+				dateAgg.Ranges = append(dateAgg.Ranges, expr)
+			}
+			return &types.Aggregations{DateRange: dateAgg}, nil
+		}
+		rangeAgg := &types.RangeAggregation{Field: &field}
+		for _, r := range f.Range.Ranges {
+			aggRange := types.AggregationRange{Key: r.Key}
+			var err error
+			if aggRange.From, err = parseRangeBound(r.From); err != nil {
+				return nil, errors.Wrapf(err, "invalid range in facet %s", f.Range.Name)
+			}
+			if aggRange.To, err = parseRangeBound(r.To); err != nil {
+				return nil, errors.Wrapf(err, "invalid range in facet %s", f.Range.Name)
+			}
+			rangeAgg.Ranges = append(rangeAgg.Ranges, aggRange)
+		}
+		return &types.Aggregations{Range: rangeAgg}, nil
+	case f.Hierarchy != nil:
+		if f.Hierarchy.Separator == "" {
+			return nil, errors.Errorf("no separator in facet %s", f.Hierarchy.Name)
+		}
+		var prefix string
+		if f.Hierarchy.Prefix != nil {
+			prefix = *f.Hierarchy.Prefix
+		}
+		params := map[string]json.RawMessage{}
+		for key, val := range map[string]string{"field": field, "separator": f.Hierarchy.Separator, "prefix": prefix} {
+			params[key] = json.RawMessage(strconv.Quote(val))
+		}
+		return &types.Aggregations{Terms: &types.TermsAggregation{
+			Script: &types.Script{
+				Source: new(hierarchyScript),
+				Params: params,
+			},
+			Size:        &f.Hierarchy.Size,
+			MinDocCount: &f.Hierarchy.MinDocCount,
+		}}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// parseRangeBound parses the numeric bound of a range, an open bound is nil
+func parseRangeBound(bound *string) (*types.Float64, error) {
+	if bound == nil {
+		return nil, nil
+	}
+	num, err := strconv.ParseFloat(*bound, 64)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid bound '%s'", *bound)
+	}
+	return new(types.Float64(num)), nil
+}
+
+// bucketCount returns the number of top level entries of a bucket, which is
+// counted by the reverse_nested aggregation for nested facets
+func bucketCount(docCount int64, aggs map[string]types.Aggregate) int {
+	if entries, ok := aggs["entries"].(*types.ReverseNestedAggregate); ok {
+		return int(entries.DocCount)
+	}
+	return int(docCount)
+}
+
+// facetHierarchy builds the tree of the paths of a hierarchical facet.
+// Nodes without parent in the values, like the node of the prefix, are the roots of the tree.
+func facetHierarchy(values []model.FacetValue, separator string) []model.FacetValue {
+	var nodes = map[string]*model.FacetValueHierarchy{}
+	var paths = []string{}
+	for _, value := range values {
+		strValue, ok := value.(*model.FacetValueString)
+		if !ok {
+			continue
+		}
+		label := strValue.StrVal
+		if pos := strings.LastIndex(label, separator); pos >= 0 {
+			label = label[pos+len(separator):]
+		}
+		nodes[strValue.StrVal] = &model.FacetValueHierarchy{
+			Path:     strValue.StrVal,
+			Label:    label,
+			Count:    strValue.Count,
+			Children: []*model.FacetValueHierarchy{},
+		}
+		paths = append(paths, strValue.StrVal)
+	}
+	var result = make([]model.FacetValue, 0)
+	for _, path := range paths {
+		if pos := strings.LastIndex(path, separator); pos >= 0 {
+			if parent, ok := nodes[path[:pos]]; ok {
+				parent.Children = append(parent.Children, nodes[path])
+				continue
+			}
+		}
+		result = append(result, nodes[path])
+	}
+	return result
+}
+
+// facetValues converts the buckets of a facet aggregation
+func facetValues(f *model.InFacet, agg types.Aggregate) ([]model.FacetValue, error) {
+	if f != nil && f.Hierarchy != nil {
+		if _, ok := agg.(*types.NestedAggregate); !ok {
+			values, err := facetValues(&model.InFacet{Term: &model.InFacetTerm{Name: f.Hierarchy.Name}}, agg)
+			if err != nil {
+				return nil, err
+			}
+			return facetHierarchy(values, f.Hierarchy.Separator), nil
+		}
+	}
+	var values = make([]model.FacetValue, 0)
+	switch bucket := agg.(type) {
+	case *types.NestedAggregate:
+		theAgg, ok := bucket.Aggregations["theAggregation"]
+		if !ok {
+			return nil, errors.New("theAggregation not found in nested aggregate")
+		}
+		return facetValues(f, theAgg)
+	case *types.StringTermsAggregate:
+		switch bucketType1 := bucket.Buckets.(type) {
+		case []types.StringTermsBucket:
+			for _, stb := range bucketType1 {
+				switch kt := stb.Key.(type) {
+				case string:
+					values = append(values, &model.FacetValueString{
+						StrVal: kt,
+						Count:  bucketCount(stb.DocCount, stb.Aggregations),
+					})
+				case int64:
+					intVal := int(kt)
+					values = append(values, &model.FacetValueInt{
+						IntVal: intVal,
+						Count:  bucketCount(stb.DocCount, stb.Aggregations),
+					})
+				default:
+					return nil, errors.Errorf("unknown bucket key type of StringTermsBucket key %T", kt)
+				}
+			}
+			//			case map[string]any:
+		default:
+			return nil, errors.Errorf("unknown bucket type of StringTermsAggregate %T", bucketType1)
+		}
+	case *types.DateHistogramAggregate:
+		buckets, ok := bucket.Buckets.([]types.DateHistogramBucket)
+		if !ok {
+			return nil, errors.Errorf("unknown bucket type of DateHistogramAggregate %T", bucket.Buckets)
+		}
+		var next func(time.Time) time.Time
+		if f != nil && f.DateHistogram != nil {
+			next = calendarIntervals[f.DateHistogram.CalendarInterval]
+		}
+		for _, dhb := range buckets {
+			start := time.UnixMilli(dhb.Key).UTC()
+			value := &model.FacetValueRange{
+				Key:   strconv.FormatInt(dhb.Key, 10),
+				From:  new(start.Format(time.RFC3339)),
+				Count: bucketCount(dhb.DocCount, dhb.Aggregations),
+			}
+			if dhb.KeyAsString != nil {
+				value.Key = *dhb.KeyAsString
+			}
+			if next != nil {
+				value.To = new(next(start).Format(time.RFC3339))
+			}
+			values = append(values, value)
+		}
+	case *types.RangeAggregate:
+		return rangeFacetValues(bucket.Buckets)
+	case *types.DateRangeAggregate:
+		return rangeFacetValues(bucket.Buckets)
+	default:
+		return nil, errors.Errorf("unknown bucket type %T", bucket)
+	}
+	return values, nil
+}
+
+func rangeFacetValues(bucketsAny types.BucketsRangeBucket) ([]model.FacetValue, error) {
+	buckets, ok := bucketsAny.([]types.RangeBucket)
+	if !ok {
+		return nil, errors.Errorf("unknown bucket type of range aggregate %T", bucketsAny)
+	}
+	var values = make([]model.FacetValue, 0)
+	for _, rb := range buckets {
+		value := &model.FacetValueRange{
+			Count: bucketCount(rb.DocCount, rb.Aggregations),
+		}
+		if rb.Key != nil {
+			value.Key = *rb.Key
+		}
+		switch {
+		case rb.FromAsString != nil:
+			value.From = rb.FromAsString
+		case rb.From != nil:
+			value.From = new(strconv.FormatFloat(float64(*rb.From), 'f', -1, 64))
+		}
+		switch {
+		case rb.ToAsString != nil:
+			value.To = rb.ToAsString
+		case rb.To != nil:
+			value.To = new(strconv.FormatFloat(float64(*rb.To), 'f', -1, 64))
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
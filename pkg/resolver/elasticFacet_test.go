@@ -0,0 +1,201 @@
+package resolver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+func TestCreateFacetAggregation(t *testing.T) {
+	histogram := &model.InFacet{DateHistogram: &model.InFacetDateHistogram{Field: "dateadded", Name: "added", CalendarInterval: "year", MinDocCount: 1}}
+	agg, err := createFacetAggregation(histogram)
+	if err != nil {
+		t.Fatalf("createFacetAggregation() error = %v", err)
+	}
+	if agg.DateHistogram == nil || agg.DateHistogram.CalendarInterval.Name != "year" {
+		t.Errorf("createFacetAggregation() = %+v, want date histogram per year", agg)
+	}
+	histogram.DateHistogram.CalendarInterval = "decade"
+	if _, err := createFacetAggregation(histogram); err == nil {
+		t.Errorf("createFacetAggregation() with interval decade should fail")
+	}
+
+	numRange := &model.InFacet{Range: &model.InFacetRange{Field: "duration", Name: "duration", Ranges: []*model.InFacetRangeBucket{
+		{To: new("60")},
+		{From: new("60"), To: new("3600")},
+	}}}
+	if agg, err = createFacetAggregation(numRange); err != nil {
+		t.Fatalf("createFacetAggregation() error = %v", err)
+	}
+	if agg.Range == nil || len(agg.Range.Ranges) != 2 || agg.Range.Ranges[0].From != nil || *agg.Range.Ranges[1].From != 60 {
+		t.Errorf("createFacetAggregation() = %+v, want two ranges", agg.Range)
+	}
+	numRange.Range.Ranges[0].To = new("one minute")
+	if _, err := createFacetAggregation(numRange); err == nil {
+		t.Errorf("createFacetAggregation() with invalid bound should fail")
+	}
+
+	dateRange := &model.InFacet{Range: &model.InFacetRange{Field: "dateadded", Name: "decade", Date: true, Ranges: []*model.InFacetRangeBucket{
+		{Key: new("1990s"), From: new("1990"), To: new("2000")},
+	}}}
+	if agg, err = createFacetAggregation(dateRange); err != nil {
+		t.Fatalf("createFacetAggregation() error = %v", err)
+	}
+	if agg.DateRange == nil || agg.DateRange.Ranges[0].From != "1990" {
+		t.Errorf("createFacetAggregation() = %+v, want date range", agg.DateRange)
+	}
+
+	if agg, err = createFacetAggregation(&model.InFacet{Query: &model.InFilter{}}); err != nil || agg != nil {
+		t.Errorf("createFacetAggregation() without facet = %v, %v", agg, err)
+	}
+}
+
+func TestFacetValues(t *testing.T) {
+	filterAgg := &types.FilterAggregate{}
+	if err := json.Unmarshal([]byte(`{
+		"doc_count": 3,
+		"date_histogram#theAggregation": {"buckets": [
+			{"key": 631152000000, "key_as_string": "1990", "doc_count": 2},
+			{"key": 662688000000, "key_as_string": "1991", "doc_count": 1}
+		]}
+	}`), filterAgg); err != nil {
+		t.Fatalf("cannot unmarshal aggregate: %v", err)
+	}
+	facet := &model.InFacet{DateHistogram: &model.InFacetDateHistogram{Name: "year", CalendarInterval: "year"}}
+	values, err := facetValues(facet, filterAgg.Aggregations["theAggregation"])
+	if err != nil {
+		t.Fatalf("facetValues() error = %v", err)
+	}
+	if len(values) != 2 {
+		t.Fatalf("facetValues() = %d values, want 2", len(values))
+	}
+	first, ok := values[0].(*model.FacetValueRange)
+	if !ok || first.Key != "1990" || *first.From != "1990-01-01T00:00:00Z" || *first.To != "1991-01-01T00:00:00Z" || first.Count != 2 {
+		t.Errorf("facetValues() = %+v", values[0])
+	}
+
+	if err := json.Unmarshal([]byte(`{
+		"doc_count": 3,
+		"range#theAggregation": {"buckets": [
+			{"key": "*-60.0", "to": 60, "doc_count": 2},
+			{"key": "60.0-*", "from": 60, "doc_count": 1}
+		]}
+	}`), filterAgg); err != nil {
+		t.Fatalf("cannot unmarshal aggregate: %v", err)
+	}
+	values, err = facetValues(nil, filterAgg.Aggregations["theAggregation"])
+	if err != nil {
+		t.Fatalf("facetValues() error = %v", err)
+	}
+	last, ok := values[1].(*model.FacetValueRange)
+	if len(values) != 2 || !ok || *last.From != "60" || last.To != nil || last.Count != 1 {
+		t.Errorf("facetValues() = %+v", values)
+	}
+}
+
+func TestCreateFilterQueryRange(t *testing.T) {
+	query, err := createFilterQuery(&model.InFilter{RangeTerm: &model.InFilterRangeTerm{Field: "[media.video].duration", From: new("60")}})
+	if err != nil {
+		t.Fatalf("createFilterQuery() error = %v", err)
+	}
+	data, err := json.Marshal(query)
+	if err != nil {
+		t.Fatalf("cannot marshal query: %v", err)
+	}
+	want := `{"nested":{"path":"media.video","query":{"range":{"media.video.duration":{"gte":"60"}}}}}`
+	if string(data) != want {
+		t.Errorf("createFilterQuery() = %s, want %s", data, want)
+	}
+}
+
+func TestNestedFacet(t *testing.T) {
+	facet := &model.InFacet{Term: &model.InFacetTerm{Field: "[persons].name.keyword", Name: "persons", Size: 10, MinDocCount: 1}}
+	agg, err := createFacetAggregation(facet)
+	if err != nil {
+		t.Fatalf("createFacetAggregation() error = %v", err)
+	}
+	data, err := json.Marshal(agg)
+	if err != nil {
+		t.Fatalf("cannot marshal aggregation: %v", err)
+	}
+	want := `{"aggregations":{"theAggregation":{"aggregations":{"entries":{"reverse_nested":{}}},"terms":{"field":"persons.name.keyword","min_doc_count":1,"order":{"entries":"desc"},"size":10}}},"nested":{"path":"persons"}}`
+	if string(data) != want {
+		t.Errorf("createFacetAggregation() = %s, want %s", data, want)
+	}
+
+	filterAgg := &types.FilterAggregate{}
+	if err := json.Unmarshal([]byte(`{
+		"doc_count": 2,
+		"nested#theAggregation": {
+			"doc_count": 5,
+			"sterms#theAggregation": {"buckets": [
+				{"key": "Ocean, Anna", "doc_count": 3, "reverse_nested#entries": {"doc_count": 2}}
+			]}
+		}
+	}`), filterAgg); err != nil {
+		t.Fatalf("cannot unmarshal aggregate: %v", err)
+	}
+	values, err := facetValues(facet, filterAgg.Aggregations["theAggregation"])
+	if err != nil {
+		t.Fatalf("facetValues() error = %v", err)
+	}
+	if len(values) != 1 {
+		t.Fatalf("facetValues() = %d values, want 1", len(values))
+	}
+	// the person is counted once per entry, not per nested document
+	if value, ok := values[0].(*model.FacetValueString); !ok || value.StrVal != "Ocean, Anna" || value.Count != 2 {
+		t.Errorf("facetValues() = %+v", values[0])
+	}
+}
+
+func TestHierarchyFacet(t *testing.T) {
+	facet := &model.InFacet{Hierarchy: &model.InFacetHierarchy{Field: "category.keyword", Name: "category", Separator: "!!", Prefix: new("film"), MinDocCount: 1, Size: 500}}
+	agg, err := createFacetAggregation(facet)
+	if err != nil {
+		t.Fatalf("createFacetAggregation() error = %v", err)
+	}
+	if agg.Terms == nil || agg.Terms.Script == nil || agg.Terms.Field != nil {
+		t.Fatalf("createFacetAggregation() = %+v, want terms aggregation on script", agg)
+	}
+	if string(agg.Terms.Script.Params["prefix"]) != `"film"` || string(agg.Terms.Script.Params["field"]) != `"category.keyword"` {
+		t.Errorf("createFacetAggregation() script params = %v", agg.Terms.Script.Params)
+	}
+
+	filterAgg := &types.FilterAggregate{}
+	if err := json.Unmarshal([]byte(`{
+		"doc_count": 5,
+		"sterms#theAggregation": {"buckets": [
+			{"key": "film", "doc_count": 5},
+			{"key": "film!!documentary", "doc_count": 3},
+			{"key": "film!!feature", "doc_count": 2},
+			{"key": "film!!documentary!!nature", "doc_count": 1}
+		]}
+	}`), filterAgg); err != nil {
+		t.Fatalf("cannot unmarshal aggregate: %v", err)
+	}
+	values, err := facetValues(facet, filterAgg.Aggregations["theAggregation"])
+	if err != nil {
+		t.Fatalf("facetValues() error = %v", err)
+	}
+	if len(values) != 1 {
+		t.Fatalf("facetValues() = %d roots, want 1", len(values))
+	}
+	root, ok := values[0].(*model.FacetValueHierarchy)
+	if !ok || root.Path != "film" || root.Count != 5 || len(root.Children) != 2 {
+		t.Fatalf("facetValues() = %+v", values[0])
+	}
+	documentary := root.Children[0]
+	if documentary.Label != "documentary" || documentary.Count != 3 || len(documentary.Children) != 1 || documentary.Children[0].Path != "film!!documentary!!nature" {
+		t.Errorf("facetValues() child = %+v", documentary)
+	}
+
+	query, err := createFilterQuery(&model.InFilter{HierarchyTerm: &model.InFilterHierarchyTerm{Field: "category.keyword", Separator: "!!", Values: []string{"film!!documentary"}}})
+	if err != nil {
+		t.Fatalf("createFilterQuery() error = %v", err)
+	}
+	if query.Bool == nil || len(query.Bool.Should) != 2 || query.Bool.Should[1].Prefix["category.keyword"].Value != "film!!documentary!!" {
+		t.Errorf("createFilterQuery() = %+v, want term and prefix query", query)
+	}
+}
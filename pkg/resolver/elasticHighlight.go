@@ -0,0 +1,111 @@
+package resolver
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// highlightResultFields are the fields of the search result, which are filled from the highlights and inner hits
+var highlightResultFields = []string{
+	"edges.highlight",
+	"edges.media.items.matched",
+	"edges.media.items.score",
+	"edges.media.items.snippets",
+}
+
+// highlightRequested checks, if the highlights of a search are selected in the graphql query of the context
+func highlightRequested(ctx context.Context) bool {
+	if !graphql.HasOperationContext(ctx) || graphql.GetFieldContext(ctx) == nil {
+		return false
+	}
+	return graphql.AnyFieldRequested(ctx, highlightResultFields...)
+}
+
+// newHighlight creates the highlighting of the search fields, boosts are removed from the field names
+func newHighlight(fields []string, fragmentSize, fragments int) *types.Highlight {
+	highlight := &types.Highlight{
+		Fields:            map[string]types.HighlightField{},
+		FragmentSize:      &fragmentSize,
+		NumberOfFragments: &fragments,
+	}
+	for _, field := range fields {
+		field, _, _ = strings.Cut(field, "^")
+		highlight.Fields[field] = types.HighlightField{}
+	}
+	return highlight
+}
+
+// hitHighlights collects the highlight fragments of a hit and of its nested inner hits.
+// Fragments of the media fulltext are only returned, if the media is visible.
+func hitHighlights(hit *types.Hit, mediaVisible bool) []*model.Highlight {
+	fragments := map[string][]string{}
+	add := func(highlight map[string][]string) {
+		for field, frags := range highlight {
+			if !mediaVisible && strings.HasPrefix(field, "media.") {
+				continue
+			}
+			fragments[field] = append(fragments[field], frags...)
+		}
+	}
+	add(hit.Highlight)
+	for _, innerHits := range hit.InnerHits {
+		for _, innerHit := range innerHits.Hits.Hits {
+			add(innerHit.Highlight)
+		}
+	}
+	if len(fragments) == 0 {
+		return nil
+	}
+	var result = []*model.Highlight{}
+	for field, frags := range fragments {
+		result = append(result, &model.Highlight{
+			Field:     field,
+			Fragments: frags,
+		})
+	}
+	slices.SortFunc(result, func(a, b *model.Highlight) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+	return result
+}
+
+// markMatchedMedia marks the media items, which are found by the inner hits of the nested media paths.
+// The highlight fragments of the media are only returned, if the media is visible.
+func markMatchedMedia(entry *model.MediathekFullEntry, hit *types.Hit, mediaVisible bool) {
+	for _, innerHits := range hit.InnerHits {
+		for _, innerHit := range innerHits.Hits.Hits {
+			if innerHit.Nested_ == nil {
+				continue
+			}
+			mediaType, ok := strings.CutPrefix(innerHit.Nested_.Field, "media.")
+			if !ok {
+				continue
+			}
+			idx := slices.IndexFunc(entry.Media, func(ml *model.MediaList) bool { return ml.Type == mediaType })
+			if idx < 0 || innerHit.Nested_.Offset >= len(entry.Media[idx].Items) {
+				continue
+			}
+			media := entry.Media[idx].Items[innerHit.Nested_.Offset]
+			media.Matched = true
+			if innerHit.Score_ != nil {
+				media.Score = new(float64(*innerHit.Score_))
+			}
+			if !mediaVisible {
+				continue
+			}
+			var fields = []string{}
+			for field := range innerHit.Highlight {
+				fields = append(fields, field)
+			}
+			slices.Sort(fields)
+			for _, field := range fields {
+				media.Snippets = append(media.Snippets, innerHit.Highlight[field]...)
+			}
+		}
+	}
+}
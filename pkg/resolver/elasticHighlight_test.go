@@ -0,0 +1,99 @@
+package resolver
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+func TestNewHighlight(t *testing.T) {
+	highlight := newHighlight([]string{"title^4", "notes.note"}, 100, 2)
+	if len(highlight.Fields) != 2 || *highlight.FragmentSize != 100 || *highlight.NumberOfFragments != 2 {
+		t.Errorf("newHighlight() = %+v", highlight)
+	}
+	for _, field := range []string{"title", "notes.note"} {
+		if _, ok := highlight.Fields[field]; !ok {
+			t.Errorf("newHighlight() has no field %s", field)
+		}
+	}
+}
+
+func TestHitHighlights(t *testing.T) {
+	hit := &types.Hit{}
+	if err := json.Unmarshal([]byte(`{
+		"_index": "test",
+		"_id": "zotero2-1.A",
+		"highlight": {"title": ["<em>Oceanic</em> Issues"]},
+		"inner_hits": {
+			"notes": {"hits": {"hits": [
+				{"_index": "test", "highlight": {"notes.note": ["im <em>Ozean</em>"]}},
+				{"_index": "test", "highlight": {"notes.note": ["am <em>Ozean</em>"]}}
+			]}},
+			"media.pdf": {"hits": {"hits": [
+				{"_index": "test", "highlight": {"media.pdf.fulltext": ["der <em>Ozean</em>"]}}
+			]}}
+		}
+	}`), hit); err != nil {
+		t.Fatalf("cannot unmarshal hit: %v", err)
+	}
+
+	highlights := hitHighlights(hit, true)
+	if len(highlights) != 3 {
+		t.Fatalf("hitHighlights() = %d fields, want 3", len(highlights))
+	}
+	if highlights[0].Field != "media.pdf.fulltext" || highlights[1].Field != "notes.note" || highlights[2].Field != "title" {
+		t.Errorf("hitHighlights() fields = %s, %s, %s", highlights[0].Field, highlights[1].Field, highlights[2].Field)
+	}
+	if len(highlights[1].Fragments) != 2 {
+		t.Errorf("hitHighlights() notes.note = %v, want 2 fragments", highlights[1].Fragments)
+	}
+
+	// the fulltext of protected media must not leak through highlighting
+	for _, highlight := range hitHighlights(hit, false) {
+		if highlight.Field == "media.pdf.fulltext" {
+			t.Errorf("hitHighlights() without media access contains %s", highlight.Field)
+		}
+	}
+}
+
+func TestMarkMatchedMedia(t *testing.T) {
+	hit := &types.Hit{}
+	if err := json.Unmarshal([]byte(`{
+		"_index": "test",
+		"_id": "zotero2-1.A",
+		"inner_hits": {
+			"media.pdf": {"hits": {"hits": [
+				{"_index": "test", "_nested": {"field": "media.pdf", "offset": 1}, "_score": 2.5, "highlight": {"media.pdf.fulltext": ["der <em>Ozean</em>"]}}
+			]}},
+			"notes": {"hits": {"hits": [
+				{"_index": "test", "_nested": {"field": "notes", "offset": 0}, "highlight": {"notes.note": ["im <em>Ozean</em>"]}}
+			]}}
+		}
+	}`), hit); err != nil {
+		t.Fatalf("cannot unmarshal hit: %v", err)
+	}
+	newEntry := func() *model.MediathekFullEntry {
+		return &model.MediathekFullEntry{Media: []*model.MediaList{
+			{Type: "video", Items: []*model.Media{{Name: "video1"}}},
+			{Type: "pdf", Items: []*model.Media{{Name: "pdf1"}, {Name: "pdf2"}}},
+		}}
+	}
+
+	entry := newEntry()
+	markMatchedMedia(entry, hit, true)
+	pdf2 := entry.Media[1].Items[1]
+	if !pdf2.Matched || pdf2.Score == nil || *pdf2.Score != 2.5 || len(pdf2.Snippets) != 1 {
+		t.Errorf("markMatchedMedia() pdf2 = %+v", pdf2)
+	}
+	if entry.Media[0].Items[0].Matched || entry.Media[1].Items[0].Matched {
+		t.Errorf("markMatchedMedia() marked media without inner hit")
+	}
+
+	entry = newEntry()
+	markMatchedMedia(entry, hit, false)
+	if pdf2 := entry.Media[1].Items[1]; !pdf2.Matched || len(pdf2.Snippets) != 0 {
+		t.Errorf("markMatchedMedia() without media access = %+v", pdf2)
+	}
+}
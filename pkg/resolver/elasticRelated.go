@@ -0,0 +1,71 @@
+package resolver
+
+import (
+	"slices"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/pkg/sourcetype"
+)
+
+// relatedFields are the text fields compared by the more like this query
+var relatedFields = []string{"title", "abstract", "tags", "category"}
+
+// relatedExclude returns the entry itself and the entries it references, which are not related entries
+func relatedExclude(src *sourcetype.SourceData) []string {
+	var exclude = []string{src.ID}
+	for _, ref := range src.References {
+		if ref.Type == "signature" && ref.Signature != "" {
+			exclude = append(exclude, ref.Signature)
+		}
+	}
+	return exclude
+}
+
+// newRelatedQuery creates the query for entries similar to src.
+// Entries are similar by the terms of the text fields or by shared persons.
+func newRelatedQuery(index string, src *sourcetype.SourceData, filter []types.Query) *types.Query {
+	var should = []types.Query{{
+		MoreLikeThis: &types.MoreLikeThisQuery{
+			Fields:        relatedFields,
+			Like:          []types.Like{types.LikeDocument{Index_: &index, Id_: &src.ID}},
+			MinTermFreq:   new(1),
+			MinDocFreq:    new(2),
+			MaxQueryTerms: new(25),
+		},
+	}}
+	var persons = []string{}
+	for _, person := range src.Persons {
+		if person.Name != "" {
+			persons = append(persons, person.Name)
+		}
+	}
+	if len(persons) > 0 {
+		should = append(should, types.Query{Nested: &types.NestedQuery{
+			Path: "persons",
+			Query: types.Query{Terms: &types.TermsQuery{TermsQuery: map[string]types.TermsQueryField{
+				"persons.name.keyword": persons,
+			}}},
+			Boost: new(float32(2)),
+		}})
+	}
+	return &types.Query{Bool: &types.BoolQuery{
+		Filter:             filter,
+		Should:             should,
+		MinimumShouldMatch: 1,
+	}}
+}
+
+// relatedSourceQuery restricts the filter of the client to the source entry, the client can only
+// get the entries related to an entry it finds with its searches
+func relatedSourceQuery(filter []types.Query, signature string) *types.Query {
+	return &types.Query{Bool: &types.BoolQuery{
+		Filter: append(slices.Clone(filter), types.Query{Ids: &types.IdsQuery{Values: []string{signature}}}),
+	}}
+}
+
+// relatedFilter adds the exclusion of the entries to the filter
+func relatedFilter(filter []types.Query, exclude []string) []types.Query {
+	return append(slices.Clone(filter), types.Query{Bool: &types.BoolQuery{
+		MustNot: []types.Query{{Ids: &types.IdsQuery{Values: exclude}}},
+	}})
+}
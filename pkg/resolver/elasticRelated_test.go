@@ -0,0 +1,47 @@
+package resolver
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/pkg/sourcetype"
+)
+
+func TestNewRelatedQuery(t *testing.T) {
+	src := &sourcetype.SourceData{
+		ID:         "zotero2-1.A",
+		Persons:    []sourcetype.Person{{Name: "Mihaylova, Albena"}, {Name: ""}},
+		References: []sourcetype.Reference{{Type: "signature", Signature: "zotero2-2.B"}, {Type: "url", Signature: "https://example.org"}},
+	}
+	exclude := relatedExclude(src)
+	if !slices.Equal(exclude, []string{"zotero2-1.A", "zotero2-2.B"}) {
+		t.Errorf("relatedExclude() = %v", exclude)
+	}
+	base := []types.Query{{Term: map[string]types.TermQuery{"acl.meta.keyword": {Value: "global/guest"}}}}
+	filter := relatedFilter(base, exclude)
+	if len(base) != 1 || len(filter) != 2 {
+		t.Fatalf("relatedFilter() = %d filters, base %d", len(filter), len(base))
+	}
+	source := relatedSourceQuery(base, src.ID)
+	if len(base) != 1 || len(source.Bool.Filter) != 2 || !slices.Equal(source.Bool.Filter[1].Ids.Values, []string{"zotero2-1.A"}) {
+		t.Errorf("relatedSourceQuery() = %+v", source.Bool.Filter)
+	}
+	query := newRelatedQuery("fhnw_ink", src, filter)
+	data, err := json.Marshal(query)
+	if err != nil {
+		t.Fatalf("cannot marshal query: %v", err)
+	}
+	for _, want := range []string{`"more_like_this"`, `"_id":"zotero2-1.A"`, `"persons.name.keyword":["Mihaylova, Albena"]`, `"must_not":[{"ids":{"values":["zotero2-1.A","zotero2-2.B"]}}]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("newRelatedQuery() = %s, does not contain %s", data, want)
+		}
+	}
+
+	query = newRelatedQuery("fhnw_ink", &sourcetype.SourceData{ID: "zotero2-3.C"}, nil)
+	if len(query.Bool.Should) != 1 {
+		t.Errorf("newRelatedQuery() without persons = %d queries, want 1", len(query.Bool.Should))
+	}
+}
@@ -3,47 +3,66 @@ package resolver
 import (
 	"context"
 	"encoding/json"
-	"regexp"
+	"fmt"
+	"net/http"
 	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"emperror.dev/errors"
 	"github.com/bluele/gcache"
 	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
-	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/operator"
-	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
 	"github.com/je4/revcat/v2/config"
 	"github.com/je4/revcat/v2/pkg/sourcetype"
 	"github.com/je4/revcat/v2/tools/graph/model"
 	"github.com/je4/utils/v2/pkg/zLogger"
 )
 
-func NewElasticResolver(elastic *elasticsearch.TypedClient, index string, clients []*config.Client, logger zLogger.ZLogger) *ElasticResolver {
+func NewElasticResolver(elastic *elasticsearch.TypedClient, index string, pitKeepAlive time.Duration, cursorKey []byte, embedder Embedder, highlight config.HighlightConfig, suggest config.SuggestConfig, thema *Thema, profiles []*config.SearchProfile, clients []*config.Client, logger zLogger.ZLogger) *ElasticResolver {
 	r := &ElasticResolver{
-		elastic:     elastic,
-		index:       index,
-		logger:      logger,
-		objectCache: gcache.New(800).LRU().Build(),
-		client:      make(map[string]*config.Client),
+		elastic:      elastic,
+		index:        index,
+		pitKeepAlive: pitKeepAlive,
+		cursorKey:    cursorKey,
+		embedder:     embedder,
+		highlight:    highlight,
+		suggest:      suggest,
+		thema:        thema,
+		logger:       logger,
+		objectCache:  gcache.New(800).LRU().Build(),
+		client:       make(map[string]*config.Client),
+		profile:      map[string]*config.SearchProfile{DefaultSearchProfile.Name: DefaultSearchProfile},
 	}
 	for _, client := range clients {
 		r.client[client.Name] = client
 	}
+	for _, profile := range profiles {
+		r.profile[profile.Name] = profile
+	}
+	r.entries = newEntryLoader(r.loadEntries, entryLoadWait)
 	return r
 }
 
 type ElasticResolver struct {
-	elastic     *elasticsearch.TypedClient
-	logger      zLogger.ZLogger
-	index       string
-	objectCache gcache.Cache
-	client      map[string]*config.Client
-	jwtKey      string
-	jwtAlgs     []string
-	jwtMaxAge   time.Duration
+	elastic      *elasticsearch.TypedClient
+	logger       zLogger.ZLogger
+	index        string
+	pitKeepAlive time.Duration
+	cursorKey    []byte
+	embedder     Embedder
+	highlight    config.HighlightConfig
+	suggest      config.SuggestConfig
+	thema        *Thema
+	objectCache  gcache.Cache
+	entries      *entryLoader
+	client       map[string]*config.Client
+	profile      map[string]*config.SearchProfile
+	jwtKey       string
+	jwtAlgs      []string
+	jwtMaxAge    time.Duration
 }
 
 func BuildBaseFilter(client *config.Client, groups ...string) ([]types.Query, error) {
@@ -154,56 +173,65 @@ func (r *ElasticResolver) loadEntries(ctx context.Context, signatures []string)
 	return result, nil
 }
 
-var sortFieldRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.]*$`)
-
-type _sortField struct {
-	a any
+// Search is the resolver for the search field.
+func (r *ElasticResolver) Search(
+	ctx context.Context,
+	searchType string,
+	query string,
+	advancedQuery *model.InAdvancedQuery,
+	facets []*model.InFacet,
+	filter []*model.InFilter,
+	vector []float64,
+	vectorOptions *model.InVectorOptions,
+	first *int, size *int, cursor *string,
+	sort []*model.SortField,
+	autoCorrect bool,
+	explain bool,
+	collapse *model.InCollapse) (*model.SearchResult, error) {
+	return r.search(ctx, searchType, query, advancedQuery, facets, filter, vector, vectorOptions, first, size, cursor, sort, collapse, autoCorrect, explain, false, "")
 }
 
-func (s *_sortField) SortCombinationsCaster() *types.SortCombinations {
-	var sortCombinations types.SortCombinations = any(s.a)
-	return &sortCombinations
+// SearchRequest is the resolver for the searchRequest field.
+// It returns the request of the search without executing it.
+func (r *ElasticResolver) SearchRequest(
+	ctx context.Context,
+	searchType string,
+	query string,
+	advancedQuery *model.InAdvancedQuery,
+	facets []*model.InFacet,
+	filter []*model.InFilter,
+	vector []float64,
+	vectorOptions *model.InVectorOptions,
+	first *int, size *int, cursor *string,
+	sort []*model.SortField,
+	collapse *model.InCollapse) (string, error) {
+	result, err := r.search(ctx, searchType, query, advancedQuery, facets, filter, vector, vectorOptions, first, size, cursor, sort, collapse, false, false, true, "")
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return *result.Request, nil
 }
 
-var _ types.SortCombinations = (*_sortField)(nil)
-
-// Search is the resolver for the search field.
-func (r *ElasticResolver) Search(
+// search executes the search, with dryRun only the request is created and returned in the result.
+// explain and dryRun are restricted to admin clients.
+// correctedQuery is searched instead of query, the cursors of the result stay bound to query.
+func (r *ElasticResolver) search(
 	ctx context.Context,
 	searchType string,
 	query string,
+	advancedQuery *model.InAdvancedQuery,
 	facets []*model.InFacet,
 	filter []*model.InFilter,
 	vector []float64,
+	vectorOptions *model.InVectorOptions,
 	first *int, size *int, cursor *string,
-	sort []*model.SortField) (*model.SearchResult, error) {
+	sort []*model.SortField,
+	collapse *model.InCollapse,
+	autoCorrect, explain, dryRun bool,
+	correctedQuery string) (*model.SearchResult, error) {
 	if errValue := ctx.Value("error"); errValue != nil {
 		return nil, errors.Errorf("%s", errValue)
 	}
-	var from = 0
-	var num = 36
-
-	if first != nil {
-		from = *first
-	}
-	if size != nil {
-		num = *size
-	}
-	if cursor != nil && *cursor != "" {
-		crs, err := DecodeCursor(*cursor)
-		if err != nil {
-			return nil, errors.Wrapf(err, "cannot decode cursor '%s'", *cursor)
-		}
-		from = crs.From
-		num = crs.Size
-	}
-
-	if from < 0 {
-		from = 0
-	}
-	if num < 0 {
-		num = 25
-	}
 	groups, err := stringsFromContext(ctx, "groups")
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot get groups from context")
@@ -217,354 +245,147 @@ func (r *ElasticResolver) Search(
 	if !ok {
 		return nil, errors.Errorf("client '%s' not found", clientName)
 	}
-	esFilter, err := BuildBaseFilter(client, groups...)
-	if err != nil {
-		return nil, errors.Wrap(err, "cannot build base filter")
-	}
-	var esPostFilter = []*types.Query{
-		//		types.Query{Bool: &baseQuery},
-		//		types.Query{Bool: &aclQuery},
+	if (explain || dryRun) && !client.Admin {
+		return nil, errors.Errorf("client '%s' is not allowed to explain searches", clientName)
 	}
-	var esAggs = map[string]types.Aggregations{}
 
-	for _, f := range filter {
-		newFilter, err := createFilterQuery(f)
-		if err != nil {
-			return nil, errors.Wrapf(err, "cannot create filter query for %v", f)
-		}
-		esFilter = append(esFilter, *newFilter)
+	in := &SearchInput{
+		SearchType:    searchType,
+		Query:         query,
+		AdvancedQuery: advancedQuery,
+		Facets:        facets,
+		Filter:        filter,
+		Vector:        vector,
+		VectorOptions: vectorOptions,
+		First:         first,
+		Size:          size,
+		Cursor:        cursor,
+		Sort:          sort,
+		Explain:       explain,
+		Collapse:      collapse,
+		// the request of a dry run is shown with highlights
+		Highlight: dryRun || highlightRequested(ctx),
+		// the corrected query of an auto corrected search
+		CorrectedQuery: correctedQuery,
 	}
-
-	for _, f := range facets {
-		if f.Query == nil {
-			continue
-		}
-		newFilter, err := createFilterQuery(f.Query)
-		if err != nil {
-			return nil, errors.Wrapf(err, "cannot create facet filter query for %v", f)
+	if searchType == "semantic" {
+		if r.embedder == nil {
+			return nil, errors.New("semantic search needs an embedder")
 		}
-		if newFilter != nil {
-			if f.Query.BoolTerm != nil && f.Query.BoolTerm.And {
-				esFilter = append(esFilter, *newFilter)
-			} else if f.Query.ExistsTerm != nil {
-				esFilter = append(esFilter, *newFilter)
-			} else {
-				esPostFilter = append(esPostFilter, newFilter)
+		if query != "" && len(vector) == 0 {
+			if in.Embedding, err = r.embedder.Embed(ctx, query); err != nil {
+				return nil, errors.Wrapf(err, "cannot embed query '%s'", query)
 			}
 		}
 	}
+	profile, err := r.searchProfile(client)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	searchRequest, paging, err := BuildSearchRequest(in, client, groups, SearchOptions{
+		Profile:   profile,
+		Highlight: r.highlight,
+		CursorKey: r.cursorKey,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	crs := paging.cursor
+	var facetByName = map[string]*model.InFacet{}
 	for _, f := range facets {
-		facetFilter := []*types.Query{}
-		//aggInclude := []string{}
-		for _, f2 := range facets {
-			if f2.Term.Name == f.Term.Name {
-				continue
-			}
-			if f2.Query == nil {
-				continue
-			}
-			newFilter, err := createFilterQuery(f2.Query)
-			if err != nil {
-				return nil, errors.Wrapf(err, "cannot create facet filter query for %v", f2)
-			}
-			if newFilter != nil {
-				facetFilter = append(facetFilter, newFilter)
-			}
-		}
-		if f.Term != nil {
-			termAgg := &types.TermsAggregation{
-				Field:       &f.Term.Field,
-				Size:        &f.Term.Size,
-				MinDocCount: &f.Term.MinDocCount,
-				//				Exclude:     []string{"bangbang!!.*"},
-				//Include: aggInclude,
-				//			Name:  &f.Name,
-			}
-			if len(f.Term.Include) == 1 {
-				termAgg.Include = f.Term.Include[0]
-			} else {
-				if len(f.Term.Include) > 1 {
-					termAgg.Include = f.Term.Include
-					termAgg.Size = new(len(f.Term.Include))
-					termAgg.MinDocCount = new(0)
-				}
-			}
-
-			agg := types.Aggregations{
-				Aggregations: map[string]types.Aggregations{
-					"theAggregation": types.Aggregations{
-						Terms: termAgg,
-					},
-				},
-			}
-			if len(facetFilter) > 0 {
-				agg.Filter = &types.Query{
-					Bool: &types.BoolQuery{
-						Filter: []types.Query{},
-					},
-				}
-				for _, ff := range facetFilter {
-					if ff != nil {
-						agg.Filter.Bool.Filter = append(agg.Filter.Bool.Filter, *ff)
-					}
-				}
-			} else {
-				agg.Filter = &types.Query{
-					MatchAll: types.NewMatchAllQuery(),
-				}
-			}
-			esAggs[f.Term.Name] = agg
-		}
-	}
-
-	esMust := []types.Query{}
-	esShould := []types.Query{}
-	//var artistBoost float32 = 1.5
-	nestedPrefixes := []string{
-		"persons.",
-		"notes.",
-		"extra.",
-		"meta.",
-		"queries.",
-		"references.",
-		"media.audio.",
-		"media.default.",
-		"media.gpx.",
-		"media.image.",
-		"media.office.",
-		"media.pdf.",
-		"media.video.",
-		"media.webrecorder.",
-	}
-	if query != "" {
-		// 1. Definition der Felder (wie bisher)
-		fields := []string{
-			"title^4",
-			"persons.name^4",
-			"collectiontitle^2",
-			"series^2",
-			"tags^2",
-			"category^1.5",
-			"abstract^1.1",
-			"notes.title^1.2",
-			"notes.note^1.0",
-			"media.*.fulltext^1.0",
-		}
-
-		// Filterung nach searchType
-		switch searchType {
-		case "author":
-			fields = []string{"persons.name"}
-		case "estate":
-			fields = []string{"collectiontitle"}
-		case "title":
-			fields = []string{"title"}
-		case "fulltext":
-			fields = []string{"abstract^1.1", "notes.title^1.2", "notes.note^1.0", "media.*.fulltext^1.0"}
-		case "collection":
-			fields = []string{"collection"}
-		case "signature":
-			fields = []string{"signature"}
-		}
-
-		// 2. Felder nach Nested-Pfad gruppieren
-		// Wir unterscheiden zwischen Root-Feldern und Nested-Feldern (persons, notes)
-		fieldGroups := make(map[string][]string)
-		for _, f := range fields {
-			found := false
-			for _, prefix := range nestedPrefixes {
-				if strings.HasPrefix(f, prefix) {
-					path := strings.TrimSuffix(prefix, ".")
-					fieldGroups[path] = append(fieldGroups[path], f)
-					found = true
-					break
-				}
-			}
-			if !found {
-				// Alles andere (inkl. media.* da media kein nested-Typ im Mapping ist)
-				fieldGroups[""] = append(fieldGroups[""], f)
-			}
-		}
-
-		// 3. Für jede Gruppe eine Query bauen und mit "Should" (ODER) verknüpfen
-		var subQueries []types.Query
-		for path, groupFields := range fieldGroups {
-			sqs := types.Query{
-				SimpleQueryString: &types.SimpleQueryStringQuery{
-					Query:           query,
-					Fields:          groupFields,
-					DefaultOperator: &operator.Or,
-				},
-			}
-
-			if path != "" {
-				// In Nested-Query einpacken
-				subQueries = append(subQueries, types.Query{
-					Nested: &types.NestedQuery{
-						Path:  path,
-						Query: sqs,
-					},
-				})
-			} else {
-				subQueries = append(subQueries, sqs)
-			}
-		}
-
-		// 4. Die Teilabfragen in esMust einfügen (als ODER-Block)
-		if len(subQueries) > 0 {
-			esMust = append(esMust, types.Query{
-				Bool: &types.BoolQuery{
-					Should: subQueries,
-				},
-			})
-		}
-
-		esShould = append(esShould, types.Query{
-			SimpleQueryString: &types.SimpleQueryStringQuery{
-				Query: query,
-				Fields: []string{
-					"title^10",
-					"persons.name^5",
-				},
-				AnalyzeWildcard: new(true),
-			},
-		})
-		/*
-			esShould = append(esShould, types.Query{
-				Nested: &types.NestedQuery{
-					Path: "persons",
-					Query: types.Query{
-						Match: map[string]types.MatchQuery{
-							"persons.name": types.MatchQuery{
-								Query:     query,
-								Fuzziness: "AUTO",
-							},
-						},
-					},
-					Boost: &artistBoost,
-				},
-			})
-		*/
+		facetByName[facetName(f)] = f
 	}
-	if len(vector) > 0 {
-		vectorBytes, err := json.Marshal(vector)
+	if dryRun {
+		requestJSON, err := json.Marshal(searchRequest)
 		if err != nil {
-			return nil, errors.Wrap(err, "cannot marshal params")
+			return nil, errors.Wrap(err, "cannot marshal search request")
 		}
-		esMust = append(esMust, types.Query{
-			ScriptScore: &types.ScriptScoreQuery{
-				Query: types.Query{
-					Exists: &types.ExistsQuery{
-						Field: "content_vector",
-					},
-				},
-				Script: types.Script{
-					Source: new("cosineSimilarity(params.queryVector, 'content_vector')"),
-					Params: map[string]json.RawMessage{
-						"queryVector": vectorBytes,
-					},
-				},
-			},
-		})
-	}
-
-	if len(facets) > 0 {
-
+		return &model.SearchResult{
+			Edges:    []*model.MediathekFullEntry{},
+			Facets:   []*model.Facet{},
+			PageInfo: &model.PageInfo{},
+			Request:  new(string(requestJSON)),
+		}, nil
 	}
 
-	searchRequest := &search.Request{}
-	if len(esAggs) > 0 {
-		searchRequest.Aggregations = esAggs
+	// a new search gets a new point in time, so that all its pages are read from the same snapshot.
+	// it is closed again, if it is not handed out with the cursors
+	var pit = crs.PIT
+	var openedPIT string
+	var keepPIT bool
+	if pit == "" && r.pitKeepAlive > 0 {
+		pitResp, err := r.elastic.OpenPointInTime(r.index).KeepAlive(r.pitKeepAliveString()).Do(ctx)
+		if err != nil {
+			r.logger.Warn().Err(err).Msg("cannot open point in time")
+		} else {
+			pit = pitResp.Id
+			openedPIT = pit
+		}
 	}
-	if len(esPostFilter) > 0 {
-		searchRequest.PostFilter = &types.Query{
-			Bool: &types.BoolQuery{
-				Filter: []types.Query{},
-			},
+	defer func() {
+		if openedPIT != "" && !keepPIT {
+			r.closePointInTime(ctx, openedPIT)
 		}
-		for _, f := range esPostFilter {
-			if f != nil {
-				searchRequest.PostFilter.Bool.Filter = append(searchRequest.PostFilter.Bool.Filter, *f)
+	}()
+	doSearch := func(pit string) (*search.Response, error) {
+		elasticQuery := r.elastic.Search()
+		if pit != "" {
+			// a search with point in time must not name the index
+			searchRequest.Pit = &types.PointInTimeReference{
+				Id:        pit,
+				KeepAlive: r.pitKeepAliveString(),
 			}
+		} else {
+			searchRequest.Pit = nil
+			elasticQuery = elasticQuery.Index(r.index)
 		}
+		return elasticQuery.Request(searchRequest).Do(ctx)
 	}
-	if searchRequest.Query == nil {
-		searchRequest.Query = &types.Query{
-			Bool: &types.BoolQuery{
-				Filter: esFilter, // []types.Query{},
-				Must:   esMust,
-				Should: esShould,
-			},
-		}
+	resp, err := doSearch(pit)
+	if err != nil && pit != "" && pit != openedPIT && isPointInTimeMissing(err) {
+		// the point in time has expired, the sort values are still valid without it
+		r.logger.Warn().Err(err).Msgf("cannot search with point in time, retrying without")
+		pit = ""
+		resp, err = doSearch(pit)
 	}
-	sorts := []*types.SortOptions{}
-	for _, s := range sort {
-		if !sortFieldRegexp.MatchString(s.Field) {
-			return nil, errors.Errorf("invalid sort field '%s'", s.Field)
-		}
-		var order sortorder.SortOrder
-		switch strings.ToLower(s.Order) {
-		case "asc":
-			order = sortorder.Asc
-		case "desc":
-			order = sortorder.Desc
-		default:
-			order = sortorder.Asc
-		}
-		sort := &types.SortOptions{SortOptions: map[string]types.FieldSort{
-			s.Field: {Order: &order},
-		}}
-		sorts = append(sorts, sort)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot search for '%s'", query)
 	}
-	/*
-		if searchRequest.Query != nil {
-			boostQuery := &types.Query{
-				Boosting: &types.BoostingQuery{
-					Positive: searchRequest.Query,
-					Negative: &types.Query{
-						Bool: &types.BoolQuery{
-							MustNot: []types.Query{
-								types.Query{
-									Exists: &types.ExistsQuery{
-										Field: "poster",
-									},
-								},
-							},
-						},
-					},
-					NegativeBoost: 0.85,
-				},
-			}
-			searchRequest.Query = boostQuery
+	if resp.PitId != nil {
+		pit = *resp.PitId
+		if openedPIT != "" {
+			openedPIT = pit
 		}
-
-	*/
-
-	elasticQuery := r.elastic.Search().
-		Index(r.index).
-		SourceExcludes_("title_vector", "content_vector").
-		Request(searchRequest).
-		From(from).
-		Size(num)
-
-	if len(sorts) > 0 {
-		var sss []types.SortCombinations = []types.SortCombinations{}
-		for _, sort := range sorts {
-			sss = append(sss, &_sortField{a: *sort})
-		}
-		elasticQuery = elasticQuery.Sort(sss...)
 	}
-	resp, err := elasticQuery.Do(ctx)
-	if err != nil {
-		return nil, errors.Wrapf(err, "cannot search for '%s'", query)
+	if crs.Before {
+		slices.Reverse(resp.Hits.Hits)
 	}
 	var result = &model.SearchResult{
 		TotalCount: int(resp.Hits.Total.Value),
 		Edges:      make([]*model.MediathekFullEntry, 0),
 		Facets:     make([]*model.Facet, 0),
-		PageInfo:   &model.PageInfo{},
+	}
+	if explain {
+		requestJSON, err := json.Marshal(searchRequest)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot marshal search request")
+		}
+		result.Request = new(string(requestJSON))
+	}
+	if paging.vector {
+		// the result of a vector search are the k nearest neighbours, the total count may include more text hits
+		result.TotalCount = min(result.TotalCount, paging.k)
+	}
+	if collapse != nil {
+		// paging and total count are based on the groups
+		if result.TotalCount, err = collapseCount(resp.Aggregations); err != nil {
+			return nil, errors.Wrap(err, "cannot count collapse groups")
+		}
 	}
 	for name, bucketAny := range resp.Aggregations {
+		if name == collapseAggregation {
+			continue
+		}
 		facet := &model.Facet{
 			Name:   name,
 			Values: make([]model.FacetValue, 0),
@@ -577,84 +398,200 @@ func (r *ElasticResolver) Search(
 		if !ok {
 			return nil, errors.Errorf("theAggregation not found in filter aggregate %s", name)
 		}
-		switch bucket := theAgg.(type) {
-		case *types.StringTermsAggregate:
-			switch bucketType1 := bucket.Buckets.(type) {
-			case []types.StringTermsBucket:
-				for _, stb := range bucketType1 {
-					switch kt := stb.Key.(type) {
-					case string:
-						facet.Values = append(facet.Values, &model.FacetValueString{
-							StrVal: kt,
-							Count:  int(stb.DocCount),
-						})
-					case int64:
-						intVal := int(kt)
-						facet.Values = append(facet.Values, &model.FacetValueInt{
-							IntVal: intVal,
-							Count:  int(stb.DocCount),
-						})
-					default:
-						return nil, errors.Errorf("unknown bucket key type of StringTermsBucket key %T", kt)
-					}
-				}
-				//			case map[string]any:
-			default:
-				return nil, errors.Errorf("unknown bucket type of StringTermsAggregate %T", bucketType1)
-			}
-		default:
-			return nil, errors.Errorf("unknown bucket type %T", bucket)
+		if facet.Values, err = facetValues(facetByName[name], theAgg); err != nil {
+			return nil, errors.Wrapf(err, "cannot read facet %s", name)
 		}
 		result.Facets = append(result.Facets, facet)
 	}
-	r.logger.Debug().Msgf("total count %d, from %d, num %d", result.TotalCount, from, num)
-	if result.TotalCount > from+num {
-		result.PageInfo.HasNextPage = true
-		nFrom := min(from+num-1, result.TotalCount-1)
-		r.logger.Debug().Msgf("next: from %d, num %d", nFrom, num)
-		if result.PageInfo.EndCursor, err = NewCursor(nFrom, num).Encode(); err != nil {
-			return nil, errors.Wrap(err, "cannot marshal end cursor")
+	r.logger.Debug().Msgf("total count %d, from %d, num %d", result.TotalCount, paging.From, paging.Size)
+	// the point in time is not needed after the last page
+	if pit != "" && result.TotalCount <= paging.From+paging.Size {
+		r.closePointInTime(ctx, pit)
+		pit = ""
+		openedPIT = ""
+	}
+	if paging.Offset {
+		if result.PageInfo, err = newOffsetPageInfo(result.TotalCount, crs, pit, r.cursorKey); err != nil {
+			return nil, errors.Wrap(err, "cannot create page info")
+		}
+	} else {
+		var firstSort, lastSort []types.FieldValue
+		if len(resp.Hits.Hits) > 0 {
+			firstSort = resp.Hits.Hits[0].Sort
+			lastSort = resp.Hits.Hits[len(resp.Hits.Hits)-1].Sort
+		}
+		if result.PageInfo, err = newSearchAfterPageInfo(result.TotalCount, crs, firstSort, lastSort, pit, r.cursorKey); err != nil {
+			return nil, errors.Wrap(err, "cannot create page info")
 		}
 	}
-	if from > 0 {
-		result.PageInfo.HasPreviousPage = true
-		nFrom := max(from-num-1, -1)
-		r.logger.Debug().Msgf("prev: from %d, num %d", nFrom, num)
-		if result.PageInfo.StartCursor, err = NewCursor(nFrom, num).Encode(); err != nil {
-			return nil, errors.Wrap(err, "cannot marshal end cursor")
+	for _, hit := range resp.Hits.Hits {
+		entry, err := r.hitToMediathekFullEntry(&hit, groups)
+		if err != nil {
+			return nil, errors.WithStack(err)
 		}
+		if entry == nil {
+			continue
+		}
+		if collapse != nil {
+			if entry.Collapse, err = r.collapseGroup(&hit, collapse.Field, groups); err != nil {
+				return nil, errors.Wrapf(err, "cannot read collapse group of hit %s", entry.ID)
+			}
+		}
+		result.Edges = append(result.Edges, entry)
 	}
-	result.PageInfo.CurrentCursor, err = NewCursor(from, num).Encode()
-	if err != nil {
-		return nil, errors.Wrap(err, "cannot marshal current cursor")
+	if crs.Corrected != "" {
+		// the pages of an auto corrected search continue with the corrected query
+		result.CorrectedQuery = new(crs.Corrected)
 	}
-	for _, hit := range resp.Hits.Hits {
-		source := &sourcetype.SourceData{}
-		if err := json.Unmarshal(hit.Source_, source); err != nil {
-			return nil, errors.Wrapf(err, "cannot unmarshal hit %v", hit)
+	// spelling suggestions for the first page of a text search with only few hits
+	if query != "" && crs.Corrected == "" && !paging.vector && len(crs.SearchAfter) == 0 && paging.From == 0 && result.TotalCount < r.suggest.Threshold {
+		if result.Suggestions, err = r.suggestions(ctx, query, paging.filter); err != nil {
+			r.logger.Warn().Err(err).Msgf("cannot get suggestions for '%s'", query)
 		}
-		var access = make(map[string]bool)
-		var mediaProtected = false
-		for t, acls := range source.ACL {
-			t = strings.ToLower(t)
-			if t == "content" {
-				mediaProtected = !slices.Contains(acls, "global/guest")
+		if autoCorrect && len(result.Suggestions) > 0 {
+			suggestion := result.Suggestions[0].Text
+			// the cursors of the corrected result are bound to the query of the client, so that it can page with them
+			corrected, err := r.search(ctx, searchType, query, advancedQuery, facets, filter, vector, vectorOptions, first, size, nil, sort, collapse, false, explain, false, suggestion)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot search for corrected query '%s'", suggestion)
 			}
-			for _, group := range groups {
-				if slices.Contains(acls, group) {
-					access[t] = true
-					break
-				}
+			if corrected.TotalCount > result.TotalCount {
+				corrected.Suggestions = result.Suggestions
+				return corrected, nil
 			}
 		}
-		if ok, found := access["meta"]; ok && found {
-			entry := r.sourceToMediathekFullEntry(nil, source, access["content"], mediaProtected)
-			result.Edges = append(result.Edges, entry)
-		}
 	}
+	r.thema.labelResult(result)
+	keepPIT = true
 	return result, nil
 }
 
+// hitToMediathekFullEntry converts a search hit, it returns nil if the groups have no access to the entry
+func (r *ElasticResolver) hitToMediathekFullEntry(hit *types.Hit, groups []string) (*model.MediathekFullEntry, error) {
+	source := &sourcetype.SourceData{}
+	if err := json.Unmarshal(hit.Source_, source); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal hit %v", hit)
+	}
+	var access = make(map[string]bool)
+	var mediaProtected = false
+	for t, acls := range source.ACL {
+		t = strings.ToLower(t)
+		if t == "content" {
+			mediaProtected = !slices.Contains(acls, "global/guest")
+		}
+		for _, group := range groups {
+			if slices.Contains(acls, group) {
+				access[t] = true
+				break
+			}
+		}
+	}
+	if ok, found := access["meta"]; !ok || !found {
+		return nil, nil
+	}
+	entry := r.sourceToMediathekFullEntry(nil, source, access["content"], mediaProtected)
+	entry.Highlight = hitHighlights(hit, access["content"])
+	markMatchedMedia(entry, hit, access["content"])
+	if hit.Explanation_ != nil {
+		explanation, err := json.Marshal(hit.Explanation_)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot marshal explanation of hit")
+		}
+		entry.Explanation = new(string(explanation))
+	}
+	return entry, nil
+}
+
+// collapseGroup returns the group of a hit of a collapsed search with its inner hits
+func (r *ElasticResolver) collapseGroup(hit *types.Hit, field string, groups []string) (*model.CollapseGroup, error) {
+	value, err := collapseValue(hit, field)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	group := &model.CollapseGroup{
+		Value: value,
+		Hits:  []*model.MediathekFullEntry{},
+	}
+	innerHits, ok := hit.InnerHits[collapseInnerHits]
+	if !ok {
+		return group, nil
+	}
+	if innerHits.Hits.Total != nil {
+		group.TotalCount = int(innerHits.Hits.Total.Value)
+	}
+	for _, innerHit := range innerHits.Hits.Hits {
+		entry, err := r.hitToMediathekFullEntry(&innerHit, groups)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if entry != nil {
+			group.Hits = append(group.Hits, entry)
+		}
+	}
+	return group, nil
+}
+
+// suggestions returns the corrections of the query, which find documents with the filter applied
+func (r *ElasticResolver) suggestions(ctx context.Context, query string, filter []types.Query) ([]*model.Suggestion, error) {
+	suggester, err := newSuggester(query, filter, r.suggest.Size)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create suggester")
+	}
+	resp, err := r.elastic.Search().Index(r.index).Request(&search.Request{
+		Suggest: suggester,
+	}).Size(0).Do(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get suggestions for '%s'", query)
+	}
+	return suggestions(query, resp.Suggest, r.suggest.Size), nil
+}
+
+// searchProfile returns the search profile of the client
+func (r *ElasticResolver) searchProfile(client *config.Client) (*config.SearchProfile, error) {
+	name := client.SearchProfile
+	if name == "" {
+		name = DefaultSearchProfile.Name
+	}
+	profile, ok := r.profile[name]
+	if !ok {
+		return nil, errors.Errorf("search profile '%s' of client '%s' not found", name, client.Name)
+	}
+	return profile, nil
+}
+
+// closePointInTime releases a point in time, which is not used by any cursor
+func (r *ElasticResolver) closePointInTime(ctx context.Context, pit string) {
+	if _, err := r.elastic.ClosePointInTime().Id(pit).Do(ctx); err != nil {
+		r.logger.Warn().Err(err).Msg("cannot close point in time")
+	}
+}
+
+// isPointInTimeMissing reports whether a search failed because its point in time has expired or does not exist
+func isPointInTimeMissing(err error) bool {
+	var esErr *types.ElasticsearchError
+	if !errors.As(err, &esErr) {
+		return false
+	}
+	if esErr.Status == http.StatusNotFound {
+		return true
+	}
+	causes := append([]types.ErrorCause{esErr.ErrorCause}, esErr.ErrorCause.RootCause...)
+	for cause := esErr.ErrorCause.CausedBy; cause != nil; cause = cause.CausedBy {
+		causes = append(causes, *cause)
+	}
+	for _, cause := range causes {
+		if cause.Type == "search_context_missing_exception" {
+			return true
+		}
+	}
+	return false
+}
+
+// pitKeepAliveString formats the point in time keep alive as elastic time unit
+func (r *ElasticResolver) pitKeepAliveString() string {
+	return fmt.Sprintf("%ds", int64(r.pitKeepAlive.Seconds()))
+}
+
 func (r *ElasticResolver) sourceToMediathekFullEntry(ctx context.Context, src *sourcetype.SourceData, mediaVisible, mediaProtected bool) *model.MediathekFullEntry {
 	entry := &model.MediathekFullEntry{
 		ID:             src.GetID(),
@@ -718,22 +655,13 @@ func (r *ElasticResolver) sourceToMediathekFullEntry(ctx context.Context, src *s
 	entry.Base.MediaProtected = mediaProtected
 	entry.Base.MediaCount = []*model.MediaCount{}
 	media := src.GetMedia()
-	if media != nil {
-		for key, ml := range media {
-			entry.Base.MediaCount = append(entry.Base.MediaCount, &model.MediaCount{
-				Type:  key,
-				Count: len(ml),
-			})
-			mediaList := &model.MediaList{
-				Type:  key,
-				Items: make([]*model.Media, 0),
-			}
-			for _, mediaItem := range ml {
-				mediaList.Items = append(mediaList.Items, sourceMediaToMedia(&mediaItem))
-			}
-			entry.Media = append(entry.Media, mediaList)
-		}
+	for key, ml := range media {
+		entry.Base.MediaCount = append(entry.Base.MediaCount, &model.MediaCount{
+			Type:  key,
+			Count: len(ml),
+		})
 	}
+	entry.Media = sourceMediaToMediaList(src.GetID(), media, mediaVisible)
 
 	return entry
 }
@@ -770,6 +698,7 @@ func (r *ElasticResolver) MediathekEntries(ctx context.Context, signatures []str
 		}
 		if ok, found := access["meta"]; ok && found {
 			entry := r.sourceToMediathekFullEntry(ctx, &doc, access["content"], mediaProtected)
+			r.thema.labelEntry(entry.Base)
 			entries = append(entries, entry)
 		}
 	}
@@ -781,7 +710,7 @@ func (r *ElasticResolver) ReferencesFull(ctx context.Context, obj *model.Mediath
 		return nil, errors.Errorf("%s", errValue)
 	}
 	var result = make([]*model.MediathekBaseEntry, 0)
-	sr, err := r.Search(ctx, "all", "", nil, []*model.InFilter{
+	sr, err := r.Search(ctx, "all", "", nil, nil, []*model.InFilter{
 		{
 			BoolTerm: &model.InFilterBoolTerm{
 				Field:  "[references].signature.keyword",
@@ -789,7 +718,7 @@ func (r *ElasticResolver) ReferencesFull(ctx context.Context, obj *model.Mediath
 				Values: []string{obj.ID},
 			},
 		},
-	}, nil, nil, nil, nil, nil)
+	}, nil, nil, nil, nil, nil, nil, false, false, nil)
 	if err == nil {
 		for _, edge := range sr.Edges {
 			result = append(result, edge.Base)
@@ -834,10 +763,328 @@ func (r *ElasticResolver) ReferencesFull(ctx context.Context, obj *model.Mediath
 		}
 		if ok, found := access["meta"]; ok && found {
 			entry := sourceToMediathekBaseEntry(&doc)
+			r.thema.labelEntry(entry)
 			result = append(result, entry)
 		}
 	}
 	return result, nil
 }
 
+// ThemaTree counts the documents per node of the thema taxonomy, which are visible to the client
+func (r *ElasticResolver) ThemaTree(ctx context.Context, filter []*model.InFilter) ([]*model.ThemaNode, error) {
+	if errValue := ctx.Value("error"); errValue != nil {
+		return nil, errors.Errorf("%s", errValue)
+	}
+	if r.thema == nil {
+		return nil, errors.New("no thema taxonomy configured")
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	clientName, err := stringFromContext(ctx, "client")
+	if err != nil || clientName == "" {
+		return nil, errors.Wrap(err, "cannot get client from context")
+	}
+	client, ok := r.client[clientName]
+	if !ok {
+		return nil, errors.Errorf("client '%s' not found", clientName)
+	}
+	esFilter, err := BuildBaseFilter(client, groups...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot build base filter")
+	}
+	for _, f := range filter {
+		newFilter, err := createFilterQuery(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot create filter query for %v", f)
+		}
+		if newFilter != nil {
+			esFilter = append(esFilter, *newFilter)
+		}
+	}
+	// one bucket per node, a theme counts the documents of all its subthemes once
+	var buckets = map[string]types.Query{}
+	for id, values := range r.thema.nodeValues() {
+		buckets[id] = types.Query{Terms: &types.TermsQuery{TermsQuery: map[string]types.TermsQueryField{
+			r.thema.field: values,
+		}}}
+	}
+	resp, err := r.elastic.Search().Index(r.index).Request(&search.Request{
+		Query: &types.Query{Bool: &types.BoolQuery{Filter: esFilter}},
+		Aggregations: map[string]types.Aggregations{
+			"thema": {Filters: &types.FiltersAggregation{Filters: buckets}},
+		},
+	}).Size(0).Do(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot count thema categories")
+	}
+	filtersAgg, ok := resp.Aggregations["thema"].(*types.FiltersAggregate)
+	if !ok {
+		return nil, errors.Errorf("unknown thema aggregate type %T", resp.Aggregations["thema"])
+	}
+	bucketMap, ok := filtersAgg.Buckets.(map[string]types.FiltersBucket)
+	if !ok {
+		return nil, errors.Errorf("unknown thema buckets type %T", filtersAgg.Buckets)
+	}
+	var counts = map[string]int{}
+	for id, bucket := range bucketMap {
+		counts[id] = int(bucket.DocCount)
+	}
+	return r.thema.nodes(counts), nil
+}
+
+// Autocomplete returns the titles, persons, collections and tags with a word starting with prefix
+func (r *ElasticResolver) Autocomplete(ctx context.Context, prefix string, fields []string, size int) ([]*model.AutocompleteSuggestion, error) {
+	if errValue := ctx.Value("error"); errValue != nil {
+		return nil, errors.Errorf("%s", errValue)
+	}
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return []*model.AutocompleteSuggestion{}, nil
+	}
+	if size <= 0 {
+		return nil, errors.Errorf("invalid size %d", size)
+	}
+	suggestTypes, err := autocompleteTypes(fields)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	clientName, err := stringFromContext(ctx, "client")
+	if err != nil || clientName == "" {
+		return nil, errors.Wrap(err, "cannot get client from context")
+	}
+	client, ok := r.client[clientName]
+	if !ok {
+		return nil, errors.Errorf("client '%s' not found", clientName)
+	}
+	esFilter, err := BuildBaseFilter(client, groups...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot build base filter")
+	}
+	request, err := newAutocompleteRequest(prefix, suggestTypes, clientPageSize(client, size), esFilter)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create autocomplete request")
+	}
+	resp, err := r.elastic.Search().Index(r.index).Request(request).Size(0).Do(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot autocomplete '%s'", prefix)
+	}
+	return autocompleteSuggestions(suggestTypes, resp.Aggregations)
+}
+
+// Related returns the entries similar to the entry with the signature, which are visible to the client
+func (r *ElasticResolver) Related(ctx context.Context, signature string, size int) ([]*model.MediathekBaseEntry, error) {
+	if errValue := ctx.Value("error"); errValue != nil {
+		return nil, errors.Errorf("%s", errValue)
+	}
+	if size <= 0 {
+		return nil, errors.Errorf("invalid size %d", size)
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	clientName, err := stringFromContext(ctx, "client")
+	if err != nil || clientName == "" {
+		return nil, errors.Wrap(err, "cannot get client from context")
+	}
+	client, ok := r.client[clientName]
+	if !ok {
+		return nil, errors.Errorf("client '%s' not found", clientName)
+	}
+	esFilter, err := BuildBaseFilter(client, groups...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot build base filter")
+	}
+	// the source entry has to pass the base filter of the client like the hits of a search
+	countResp, err := r.elastic.Count().Index(r.index).Query(relatedSourceQuery(esFilter, signature)).Do(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot check entry %s", signature)
+	}
+	if countResp.Count == 0 {
+		return nil, errors.Errorf("entry %s not found", signature)
+	}
+	docs, err := r.loadEntries(ctx, []string{signature})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load entry %s", signature)
+	}
+	if len(docs) == 0 {
+		return nil, errors.Errorf("entry %s not found", signature)
+	}
+	src := &docs[0]
+	if access, _ := aclAccess(src.ACL, groups); !access["meta"] {
+		return nil, errors.Errorf("entry %s not found", signature)
+	}
+	esFilter = relatedFilter(esFilter, relatedExclude(src))
+	num := clientPageSize(client, size)
+
+	searchRequest := &search.Request{}
+	query := newRelatedQuery(r.index, src, esFilter)
+	vector, err := r.contentVector(ctx, signature)
+	if err != nil {
+		r.logger.Warn().Err(err).Msgf("cannot load content vector of %s", signature)
+	}
+	if len(vector) > 0 {
+		knn, err := newKnnSearch(vector, nil, esFilter, num)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot create knn search")
+		}
+		searchRequest.Retriever = newHybridRetriever(query, knn)
+	} else {
+		searchRequest.Query = query
+	}
+	resp, err := r.elastic.Search().Index(r.index).
+		SourceExcludes_(searchSourceExcludes...).
+		Request(searchRequest).
+		Size(num).
+		Do(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot search entries related to %s", signature)
+	}
+	var result = []*model.MediathekBaseEntry{}
+	for _, hit := range resp.Hits.Hits {
+		source := &sourcetype.SourceData{}
+		if err := json.Unmarshal(hit.Source_, source); err != nil {
+			return nil, errors.Wrapf(err, "cannot unmarshal hit %v", hit)
+		}
+		access, mediaProtected := aclAccess(source.ACL, groups)
+		if !access["meta"] {
+			continue
+		}
+		entry := sourceToMediathekBaseEntry(source)
+		entry.MediaVisible = access["content"]
+		entry.MediaProtected = mediaProtected
+		r.thema.labelEntry(entry)
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// contentVector loads the content vector of an entry, which is nil if the entry has no vector
+func (r *ElasticResolver) contentVector(ctx context.Context, signature string) ([]float64, error) {
+	resp, err := r.elastic.Get(r.index, signature).SourceIncludes_("content_vector").Do(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot get %s", signature)
+	}
+	if !resp.Found || resp.Source_ == nil {
+		return nil, nil
+	}
+	var source = struct {
+		ContentVector []float64 `json:"content_vector"`
+	}{}
+	if err := json.Unmarshal(resp.Source_, &source); err != nil {
+		return nil, errors.Wrapf(err, "cannot unmarshal content vector of %s", signature)
+	}
+	return source.ContentVector, nil
+}
+
+// SearchInEntry returns the media items of the entry, whose fulltext matches the query
+func (r *ElasticResolver) SearchInEntry(ctx context.Context, signature string, query string, size int) ([]*model.MediaMatch, error) {
+	if errValue := ctx.Value("error"); errValue != nil {
+		return nil, errors.Errorf("%s", errValue)
+	}
+	if size <= 0 {
+		return nil, errors.Errorf("invalid size %d", size)
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []*model.MediaMatch{}, nil
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	clientName, err := stringFromContext(ctx, "client")
+	if err != nil || clientName == "" {
+		return nil, errors.Wrap(err, "cannot get client from context")
+	}
+	client, ok := r.client[clientName]
+	if !ok {
+		return nil, errors.Errorf("client '%s' not found", clientName)
+	}
+	docs, err := r.loadEntries(ctx, []string{signature})
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load entry %s", signature)
+	}
+	if len(docs) == 0 {
+		return nil, errors.Errorf("entry %s not found", signature)
+	}
+	src := &docs[0]
+	access, _ := aclAccess(src.ACL, groups)
+	if !access["meta"] {
+		return nil, errors.Errorf("entry %s not found", signature)
+	}
+	if !access["content"] {
+		return nil, errors.Errorf("no access to the media of entry %s", signature)
+	}
+	mediaTypes := fulltextMediaTypes(src)
+	if len(mediaTypes) == 0 {
+		return []*model.MediaMatch{}, nil
+	}
+	num := min(clientPageSize(client, size), searchInEntryMaxSize)
+	resp, err := r.elastic.Search().Index(r.index).
+		Request(newSearchInEntryRequest(signature, query, mediaTypes, num, r.highlight)).
+		Size(1).
+		Do(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot search '%s' in entry %s", query, signature)
+	}
+	matches := searchInEntryMatches(src, resp.Hits.Hits)
+	return matches[:min(num, len(matches))], nil
+}
+
+// MediaFulltext returns a part of the fulltext of the media
+func (r *ElasticResolver) MediaFulltext(ctx context.Context, obj *model.Media, offset int, length *int) (*string, error) {
+	fulltext, err := r.mediaFulltext(ctx, obj)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return fulltextPage(fulltext, offset, length)
+}
+
+// MediaFulltextLength returns the number of characters of the fulltext of the media
+func (r *ElasticResolver) MediaFulltextLength(ctx context.Context, obj *model.Media) (int, error) {
+	fulltext, err := r.mediaFulltext(ctx, obj)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	if fulltext == nil {
+		return 0, nil
+	}
+	return utf8.RuneCountInString(*fulltext), nil
+}
+
+// mediaFulltext returns the fulltext of the media.
+// Search results do not contain the fulltext, it is loaded from the entry if the groups have access to its content.
+// The entries of all media resolved at the same time are loaded together.
+func (r *ElasticResolver) mediaFulltext(ctx context.Context, obj *model.Media) (*string, error) {
+	if obj.Fulltext != nil || obj.Signature == "" {
+		return obj.Fulltext, nil
+	}
+	groups, err := stringsFromContext(ctx, "groups")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot get groups from context")
+	}
+	doc, err := r.entries.Load(ctx, obj.Signature)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot load entry %s", obj.Signature)
+	}
+	if access, _ := aclAccess(doc.ACL, groups); !access["meta"] || !access["content"] {
+		return nil, nil
+	}
+	mediaList := doc.Media[obj.List]
+	if obj.Index < 0 || obj.Index >= len(mediaList) {
+		return nil, errors.Errorf("media %s[%d] of entry %s not found", obj.List, obj.Index, obj.Signature)
+	}
+	if mediaList[obj.Index].Fulltext == "" {
+		return nil, nil
+	}
+	return new(mediaList[obj.Index].Fulltext), nil
+}
+
 var _ Resolver = (*ElasticResolver)(nil)
@@ -0,0 +1,69 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/config"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+func clientContext(client string) context.Context {
+	ctx := context.WithValue(context.Background(), "groups", []string{"global/guest"})
+	return context.WithValue(ctx, "client", client)
+}
+
+func TestElasticResolver_SearchRequest(t *testing.T) {
+	clients := []*config.Client{{Name: "admin", Admin: true}, {Name: "guest"}}
+	r := NewElasticResolver(nil, "test", 0, []byte("test"), nil, config.HighlightConfig{}, config.SuggestConfig{}, nil, nil, clients, nil)
+	size := 10
+	sort := []*model.SortField{{Field: "title.keyword", Order: "desc"}}
+	requestJSON, err := r.SearchRequest(clientContext("admin"), "all", "", nil, nil, nil, nil, nil, nil, &size, nil, sort, nil)
+	if err != nil {
+		t.Fatalf("cannot create search request: %v", err)
+	}
+	var request map[string]any
+	if err := json.Unmarshal([]byte(requestJSON), &request); err != nil {
+		t.Fatalf("invalid search request %s: %v", requestJSON, err)
+	}
+	if request["size"] != float64(10) || request["from"] != float64(0) || request["track_total_hits"] != true {
+		t.Errorf("wrong paging in search request %s", requestJSON)
+	}
+	sorts, ok := request["sort"].([]any)
+	if !ok || len(sorts) != 2 {
+		t.Fatalf("sort with tiebreaker expected in search request %s", requestJSON)
+	}
+	if _, ok := sorts[0].(map[string]any)["title.keyword"]; !ok {
+		t.Errorf("sort by title expected in search request %s", requestJSON)
+	}
+	if _, ok := request["explain"]; ok {
+		t.Errorf("no explain expected in search request %s", requestJSON)
+	}
+
+	if _, err := r.SearchRequest(clientContext("guest"), "all", "", nil, nil, nil, nil, nil, nil, &size, nil, sort, nil); err == nil {
+		t.Error("search request of client without admin flag should fail")
+	}
+	if _, err := r.Search(clientContext("guest"), "all", "", nil, nil, nil, nil, nil, nil, &size, nil, sort, false, true, nil); err == nil {
+		t.Error("explain of client without admin flag should fail")
+	}
+}
+
+func TestIsPointInTimeMissing(t *testing.T) {
+	expired := &types.ElasticsearchError{Status: 500, ErrorCause: types.ErrorCause{
+		Type:      "search_phase_execution_exception",
+		RootCause: []types.ErrorCause{{Type: "search_context_missing_exception"}},
+	}}
+	if !isPointInTimeMissing(errors.Wrap(expired, "cannot search")) {
+		t.Error("expired point in time not detected")
+	}
+	if !isPointInTimeMissing(&types.ElasticsearchError{Status: 404, ErrorCause: types.ErrorCause{Type: "resource_not_found_exception"}}) {
+		t.Error("missing point in time not detected")
+	}
+	badQuery := &types.ElasticsearchError{Status: 400, ErrorCause: types.ErrorCause{Type: "parsing_exception"}}
+	if isPointInTimeMissing(badQuery) || isPointInTimeMissing(errors.New("timeout")) {
+		t.Error("other errors must not be reported as missing point in time")
+	}
+}
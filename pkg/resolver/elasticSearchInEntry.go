@@ -0,0 +1,91 @@
+package resolver
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/operator"
+	"github.com/je4/revcat/v2/config"
+	"github.com/je4/revcat/v2/pkg/sourcetype"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// searchInEntryMaxSize is the maximum number of inner hits elastic returns per nested path
+const searchInEntryMaxSize = 100
+
+// fulltextMediaTypes returns the sorted media types of the entry, which have media with fulltext
+func fulltextMediaTypes(src *sourcetype.SourceData) []string {
+	var result = []string{}
+	for mediaType, ml := range src.Media {
+		if slices.ContainsFunc(ml, func(m sourcetype.Media) bool { return m.Fulltext != "" }) {
+			result = append(result, mediaType)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// newSearchInEntryRequest creates the search for the media of the entry with fulltext matching the query.
+// The matching media items are returned as highlighted inner hits of the nested media paths.
+func newSearchInEntryRequest(signature, query string, mediaTypes []string, size int, highlight config.HighlightConfig) *search.Request {
+	var should = []types.Query{}
+	for _, mediaType := range mediaTypes {
+		path := "media." + mediaType
+		field := path + ".fulltext"
+		should = append(should, types.Query{Nested: &types.NestedQuery{
+			Path: path,
+			Query: types.Query{SimpleQueryString: &types.SimpleQueryStringQuery{
+				Query:           query,
+				Fields:          []string{field},
+				DefaultOperator: &operator.And,
+			}},
+			InnerHits: &types.InnerHits{
+				Name:      new(path),
+				Size:      new(size),
+				Source_:   false,
+				Highlight: newHighlight([]string{field}, highlight.FragmentSize, highlight.Fragments),
+			},
+		}})
+	}
+	return &search.Request{
+		Query: &types.Query{Bool: &types.BoolQuery{
+			Filter:             []types.Query{{Ids: &types.IdsQuery{Values: []string{signature}}}},
+			Should:             should,
+			MinimumShouldMatch: 1,
+		}},
+		Source_: false,
+	}
+}
+
+// searchInEntryMatches converts the inner hits of the media paths to the matches of the media items of src
+func searchInEntryMatches(src *sourcetype.SourceData, hits []types.Hit) []*model.MediaMatch {
+	var result = []*model.MediaMatch{}
+	for _, hit := range hits {
+		for _, innerHits := range hit.InnerHits {
+			for _, innerHit := range innerHits.Hits.Hits {
+				if innerHit.Nested_ == nil {
+					continue
+				}
+				mediaType, ok := strings.CutPrefix(innerHit.Nested_.Field, "media.")
+				if !ok || innerHit.Nested_.Offset >= len(src.Media[mediaType]) {
+					continue
+				}
+				sourceMedia := src.Media[mediaType][innerHit.Nested_.Offset]
+				var snippets = []string{}
+				for _, fragments := range innerHit.Highlight {
+					snippets = append(snippets, fragments...)
+				}
+				var score *float64
+				if innerHit.Score_ != nil {
+					score = new(float64(*innerHit.Score_))
+				}
+				positions := fulltextPositions(sourceMedia.Fulltext, highlightedTerms(snippets))
+				result = append(result, newMediaMatch(mediaType, innerHit.Nested_.Offset, sourceMediaToMedia(&sourceMedia), score, snippets, positions))
+			}
+		}
+	}
+	sortMediaMatches(result)
+	return result
+}
@@ -0,0 +1,69 @@
+package resolver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/config"
+	"github.com/je4/revcat/v2/pkg/sourcetype"
+)
+
+func TestNewSearchInEntryRequest(t *testing.T) {
+	src := &sourcetype.SourceData{ID: "zotero2-1.A", Media: map[string]sourcetype.MediaList{
+		"video": {{Name: "video1", Fulltext: "Transkript"}},
+		"pdf":   {{Name: "pdf1", Fulltext: "Programm"}},
+		"image": {{Name: "image1"}},
+	}}
+	mediaTypes := fulltextMediaTypes(src)
+	if len(mediaTypes) != 2 || mediaTypes[0] != "pdf" || mediaTypes[1] != "video" {
+		t.Fatalf("fulltextMediaTypes() = %v, want [pdf video]", mediaTypes)
+	}
+	request := newSearchInEntryRequest(src.ID, "walzer", mediaTypes, 10, config.HighlightConfig{FragmentSize: 150, Fragments: 3})
+	data, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("cannot marshal request: %v", err)
+	}
+	for _, want := range []string{
+		`"_source":false`,
+		`{"ids":{"values":["zotero2-1.A"]}}`,
+		`"path":"media.pdf"`,
+		`"name":"media.video"`,
+		`"fields":["media.video.fulltext"]`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("newSearchInEntryRequest() = %s, does not contain %s", data, want)
+		}
+	}
+}
+
+func TestSearchInEntryMatches(t *testing.T) {
+	src := &sourcetype.SourceData{ID: "zotero2-1.A", Media: map[string]sourcetype.MediaList{
+		"pdf": {{Name: "pdf1", Fulltext: "Programm"}, {Name: "pdf2", Fulltext: "Walzer und Tango"}},
+	}}
+	hit := types.Hit{}
+	if err := json.Unmarshal([]byte(`{
+		"_index": "test",
+		"_id": "zotero2-1.A",
+		"inner_hits": {
+			"media.pdf": {"hits": {"hits": [
+				{"_index": "test", "_nested": {"field": "media.pdf", "offset": 1}, "_score": 1.5, "highlight": {"media.pdf.fulltext": ["<em>Walzer</em> und Tango"]}},
+				{"_index": "test", "_nested": {"field": "media.pdf", "offset": 7}, "_score": 1.0}
+			]}}
+		}
+	}`), &hit); err != nil {
+		t.Fatalf("cannot unmarshal hit: %v", err)
+	}
+	matches := searchInEntryMatches(src, []types.Hit{hit})
+	if len(matches) != 1 {
+		t.Fatalf("searchInEntryMatches() = %d matches, want 1", len(matches))
+	}
+	match := matches[0]
+	if match.Type != "pdf" || match.Index != 1 || match.Media.Name != "pdf2" || *match.Score != 1.5 || !match.Media.Matched {
+		t.Errorf("searchInEntryMatches() = %+v", match)
+	}
+	if len(match.Positions) != 1 || match.Positions[0] != 0 || len(match.Snippets) != 1 {
+		t.Errorf("searchInEntryMatches() positions = %v, snippets = %v", match.Positions, match.Snippets)
+	}
+}
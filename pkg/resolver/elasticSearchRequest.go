@@ -0,0 +1,375 @@
+package resolver
+
+import (
+	"regexp"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/sortorder"
+	"github.com/je4/revcat/v2/config"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+var sortFieldRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.]*$`)
+
+// searchSourceExcludes are the fields not returned with the hits of a search.
+// The media fulltext is loaded with the paginated fulltext field of the media.
+var searchSourceExcludes = []string{"title_vector", "content_vector", "media.*.fulltext"}
+
+// SearchInput contains the arguments of the search field
+type SearchInput struct {
+	SearchType    string
+	Query         string
+	AdvancedQuery *model.InAdvancedQuery
+	Facets        []*model.InFacet
+	Filter        []*model.InFilter
+	Vector        []float64
+	VectorOptions *model.InVectorOptions
+	First         *int
+	Size          *int
+	Cursor        *string
+	Sort          []*model.SortField
+	Explain       bool
+	Collapse      *model.InCollapse
+	// Highlight requests the highlights of the hits and the inner hits of the matching nested documents
+	Highlight bool
+	// CorrectedQuery is searched instead of Query, the cursors stay bound to Query and keep the correction
+	CorrectedQuery string
+	// Embedding is the vector of the query for semantic search without vector, it is created by the caller
+	Embedding []float64
+}
+
+// SearchOptions are the settings of the server used for building search requests
+type SearchOptions struct {
+	Profile   *config.SearchProfile
+	Highlight config.HighlightConfig
+	CursorKey []byte
+}
+
+// SearchPaging describes the result page of a search request
+type SearchPaging struct {
+	From int
+	Size int
+	// Offset is set for vector and collapsed searches, they are paged by offset instead of search after
+	Offset bool
+	cursor *cursor
+	// vector is set for vector and hybrid searches
+	vector bool
+	// k is the number of nearest neighbours of a vector search, it is the maximum total count
+	k int
+	// filter is the filter of the query, it is used for the spelling suggestions
+	filter []types.Query
+}
+
+// BuildSearchRequest creates the elastic request of a search for the client with the groups.
+// It does no network requests, the query of a semantic search has to be embedded by the caller.
+func BuildSearchRequest(in *SearchInput, client *config.Client, groups []string, opts SearchOptions) (*search.Request, *SearchPaging, error) {
+	if client == nil {
+		return nil, nil, errors.New("no client")
+	}
+	profile := opts.Profile
+	if profile == nil {
+		profile = DefaultSearchProfile
+	}
+	paging, err := newSearchPaging(in, client, opts.CursorKey)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	crs := paging.cursor
+
+	esFilter, err := BuildBaseFilter(client, groups...)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot build base filter")
+	}
+	for _, f := range in.Filter {
+		newFilter, err := createFilterQuery(f)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "cannot create filter query for %v", f)
+		}
+		esFilter = append(esFilter, *newFilter)
+	}
+	facetQueries, postFilter, err := facetFilters(in.Facets)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	esFilter = append(esFilter, facetQueries...)
+	esAggs, err := facetAggregations(in.Facets)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	query := in.Query
+	if crs.Corrected != "" {
+		query = crs.Corrected
+	}
+	esMust := []types.Query{}
+	esShould := []types.Query{}
+	var highlight *types.Highlight
+	if query != "" {
+		if esMust, esShould, highlight, err = newTextQuery(profile, in.SearchType, query, opts.Highlight, in.Highlight); err != nil {
+			return nil, nil, errors.Wrapf(err, "cannot create query for '%s'", query)
+		}
+	} else if _, err := searchProfileFields(profile, in.SearchType); err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if in.AdvancedQuery != nil {
+		advQuery, err := createAdvancedQuery(in.AdvancedQuery)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "invalid advanced query")
+		}
+		esMust = append(esMust, *advQuery)
+	}
+	vector := in.Vector
+	if in.SearchType == "semantic" {
+		if len(vector) == 0 {
+			vector = in.Embedding
+		}
+		// the query is searched by its meaning only
+		query = ""
+	}
+	var knn *types.KnnSearch
+	size := crs.Size
+	if len(vector) > 0 {
+		if knn, err = newKnnSearch(vector, in.VectorOptions, esFilter, crs.Size); err != nil {
+			return nil, nil, errors.Wrap(err, "cannot create knn search")
+		}
+		// there are no hits beyond the k nearest neighbours
+		if crs.From > 0 && crs.From >= *knn.K {
+			return nil, nil, errors.Errorf("page at %d is beyond the %d nearest neighbours", crs.From, *knn.K)
+		}
+		size = min(size, *knn.K-crs.From)
+		paging.Offset = true
+		paging.vector = true
+		paging.k = *knn.K
+	}
+	paging.filter = esFilter
+
+	searchRequest := &search.Request{}
+	searchRequest.Highlight = highlight
+	if len(esAggs) > 0 {
+		searchRequest.Aggregations = esAggs
+	}
+	if len(postFilter) > 0 {
+		searchRequest.PostFilter = &types.Query{
+			Bool: &types.BoolQuery{
+				Filter: postFilter,
+			},
+		}
+	}
+	textQuery := &types.Query{
+		Bool: &types.BoolQuery{
+			Filter: esFilter,
+			Must:   esMust,
+			Should: esShould,
+		},
+	}
+	switch {
+	case knn != nil && query != "":
+		// hybrid search, text and vector hits are ranked with reciprocal rank fusion
+		searchRequest.Retriever = newHybridRetriever(textQuery, knn)
+	case knn != nil:
+		searchRequest.Knn = []types.KnnSearch{*knn}
+	default:
+		searchRequest.Query = textQuery
+	}
+	sorts, err := searchSort(in.Sort, crs.Before, knn != nil)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	searchRequest.Sort = sorts
+	if in.Collapse != nil {
+		if knn != nil {
+			return nil, nil, errors.New("vector search cannot be collapsed")
+		}
+		collapse, collapseAgg, err := newCollapse(in.Collapse, sorts, searchRequest.PostFilter)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		searchRequest.Collapse = collapse
+		if searchRequest.Aggregations == nil {
+			searchRequest.Aggregations = map[string]types.Aggregations{}
+		}
+		if _, ok := searchRequest.Aggregations[collapseAggregation]; ok {
+			return nil, nil, errors.Errorf("facet name '%s' is reserved for collapse", collapseAggregation)
+		}
+		searchRequest.Aggregations[collapseAggregation] = *collapseAgg
+		// the groups are counted and paged by offset
+		paging.Offset = true
+	}
+
+	searchRequest.Size = &size
+	searchRequest.TrackTotalHits = true
+	searchRequest.Source_ = types.SourceFilter{Excludes: searchSourceExcludes}
+	if in.Explain {
+		searchRequest.Explain = new(true)
+	}
+	if len(crs.SearchAfter) > 0 {
+		searchRequest.SearchAfter = crs.SearchAfter
+	} else {
+		searchRequest.From = &crs.From
+	}
+	return searchRequest, paging, nil
+}
+
+// newSearchPaging reads the page of the search from the cursor or from first and size
+func newSearchPaging(in *SearchInput, client *config.Client, cursorKey []byte) (*SearchPaging, error) {
+	hash, err := queryHash(client.Name, in.SearchType, in.Query, in.AdvancedQuery, in.Facets, in.Filter, in.Vector, in.VectorOptions, in.Sort, in.Collapse)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create query hash")
+	}
+	var from = 0
+	var num = defaultPageSize
+	if in.First != nil {
+		from = *in.First
+	}
+	if in.Size != nil {
+		num = *in.Size
+	}
+	crs := NewCursor(from, num)
+	if in.Cursor != nil && *in.Cursor != "" {
+		crs, err = DecodeCursor(*in.Cursor, cursorKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot decode cursor '%s'", *in.Cursor)
+		}
+		if crs.QueryHash != hash {
+			return nil, errors.Errorf("cursor '%s' does not belong to this search", *in.Cursor)
+		}
+		from = crs.From
+		num = crs.Size
+	}
+	if from < 0 {
+		from = 0
+	}
+	if num < 0 {
+		num = defaultPageSize
+	}
+	num = clientPageSize(client, num)
+	crs.From = from
+	crs.Size = num
+	crs.QueryHash = hash
+	if in.CorrectedQuery != "" {
+		crs.Corrected = in.CorrectedQuery
+	}
+	return &SearchPaging{From: from, Size: num, cursor: crs}, nil
+}
+
+// facetFilters returns the filters of the selected facet values.
+// Values of "and" facets and exists facets restrict the query and the facet counts, values of the other
+// facets are post filters, so that the counts of their alternative values remain visible.
+func facetFilters(facets []*model.InFacet) (filter []types.Query, postFilter []types.Query, err error) {
+	for _, f := range facets {
+		query := facetFilter(f)
+		if query == nil {
+			continue
+		}
+		newFilter, err := createFilterQuery(query)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "cannot create facet filter query for %v", f)
+		}
+		if (query.BoolTerm != nil && query.BoolTerm.And) || query.ExistsTerm != nil {
+			filter = append(filter, *newFilter)
+		} else {
+			postFilter = append(postFilter, *newFilter)
+		}
+	}
+	return filter, postFilter, nil
+}
+
+// facetAggregations creates the aggregations of the facets.
+// The counts of a facet are filtered by the selected values of all other facets.
+func facetAggregations(facets []*model.InFacet) (map[string]types.Aggregations, error) {
+	var esAggs = map[string]types.Aggregations{}
+	for _, f := range facets {
+		name := facetName(f)
+		otherFilters := []types.Query{}
+		for _, f2 := range facets {
+			if facetName(f2) == name {
+				continue
+			}
+			query := facetFilter(f2)
+			if query == nil {
+				continue
+			}
+			newFilter, err := createFilterQuery(query)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot create facet filter query for %v", f2)
+			}
+			otherFilters = append(otherFilters, *newFilter)
+		}
+		facetAgg, err := createFacetAggregation(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot create facet aggregation for %v", f)
+		}
+		if facetAgg == nil {
+			continue
+		}
+		agg := types.Aggregations{
+			Aggregations: map[string]types.Aggregations{
+				"theAggregation": *facetAgg,
+			},
+		}
+		if len(otherFilters) > 0 {
+			agg.Filter = &types.Query{
+				Bool: &types.BoolQuery{
+					Filter: otherFilters,
+				},
+			}
+		} else {
+			agg.Filter = &types.Query{
+				MatchAll: types.NewMatchAllQuery(),
+			}
+		}
+		esAggs[name] = agg
+	}
+	return esAggs, nil
+}
+
+// searchSort validates the sort fields and creates the sort of the request.
+// search_after needs a total order of the hits, so the signature is added as tiebreaker.
+// A search before is a search after with reversed sort order. Vector searches are ranked by score only.
+func searchSort(sort []*model.SortField, before, vector bool) ([]types.SortCombinations, error) {
+	sorts := []types.SortCombinations{}
+	newSort := func(field string, order sortorder.SortOrder) types.SortOptions {
+		if before {
+			if order == sortorder.Desc {
+				order = sortorder.Asc
+			} else {
+				order = sortorder.Desc
+			}
+		}
+		return types.SortOptions{SortOptions: map[string]types.FieldSort{
+			field: {Order: &order},
+		}}
+	}
+	var hasTiebreaker = false
+	for _, s := range sort {
+		if !sortFieldRegexp.MatchString(s.Field) {
+			return nil, errors.Errorf("invalid sort field '%s'", s.Field)
+		}
+		if vector && s.Field != "_score" {
+			return nil, errors.Errorf("vector search cannot be sorted by '%s'", s.Field)
+		}
+		var order sortorder.SortOrder
+		switch strings.ToLower(s.Order) {
+		case "desc":
+			order = sortorder.Desc
+		default:
+			order = sortorder.Asc
+		}
+		sorts = append(sorts, newSort(s.Field, order))
+		hasTiebreaker = hasTiebreaker || s.Field == "signature.keyword"
+	}
+	// vector search ranks the nearest neighbours and is paged by offset
+	if vector {
+		return nil, nil
+	}
+	if len(sorts) == 0 {
+		sorts = append(sorts, newSort("_score", sortorder.Desc))
+	}
+	if !hasTiebreaker {
+		sorts = append(sorts, newSort("signature.keyword", sortorder.Asc))
+	}
+	return sorts, nil
+}
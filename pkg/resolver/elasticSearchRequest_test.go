@@ -0,0 +1,229 @@
+package resolver
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/config"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+var testSearchOptions = SearchOptions{
+	Highlight: config.HighlightConfig{FragmentSize: 150, Fragments: 3},
+	CursorKey: []byte("test"),
+}
+
+func termFacet(field, name string, and bool, values ...string) *model.InFacet {
+	return &model.InFacet{
+		Term:  &model.InFacetTerm{Field: field, Name: name, MinDocCount: 1, Size: 10},
+		Query: &model.InFilter{BoolTerm: &model.InFilterBoolTerm{Field: field, And: and, Values: values}},
+	}
+}
+
+func testCursor(t *testing.T, in *SearchInput, client *config.Client, crs *cursor) *string {
+	t.Helper()
+	hash, err := queryHash(client.Name, in.SearchType, in.Query, in.AdvancedQuery, in.Facets, in.Filter, in.Vector, in.VectorOptions, in.Sort, in.Collapse)
+	if err != nil {
+		t.Fatalf("cannot create query hash: %v", err)
+	}
+	crs.QueryHash = hash
+	str, err := crs.Encode(testSearchOptions.CursorKey)
+	if err != nil {
+		t.Fatalf("cannot encode cursor: %v", err)
+	}
+	return &str
+}
+
+func TestBuildSearchRequest(t *testing.T) {
+	guest := &config.Client{Name: "guest"}
+	restricted := &config.Client{Name: "restricted", MaxPageSize: 10, AND: []config.ClientANDQuery{{
+		OR: []config.ClientOrQuery{{Field: "category.keyword", Values: []string{"zotero2!!Performance Art"}}},
+	}}}
+	searchAfter := &SearchInput{Query: "ocean", Sort: []*model.SortField{{Field: "title.keyword", Order: "asc"}}}
+	searchAfter.Cursor = testCursor(t, searchAfter, guest, &cursor{From: 36, Size: 36, SearchAfter: []types.FieldValue{"Oceanic", "zotero-2486551.TTK4DKKT"}, Before: true})
+
+	tests := []struct {
+		name   string
+		in     *SearchInput
+		client *config.Client
+		paging SearchPaging
+	}{
+		{
+			name:   "text",
+			in:     &SearchInput{SearchType: "all", Query: "oceanic feelings", Highlight: true},
+			client: guest,
+			paging: SearchPaging{From: 0, Size: 36},
+		},
+		{
+			// "or" facets are post filters and filter the counts of the other facets only,
+			// "and" facets filter the query
+			name: "facets",
+			in: &SearchInput{Facets: []*model.InFacet{
+				termFacet("tags.keyword", "tags", false, "performance", "video"),
+				termFacet("category.keyword", "category", true, "zotero2!!Performance Art"),
+				termFacet("mediatype.keyword", "mediatype", false),
+			}},
+			client: guest,
+			paging: SearchPaging{From: 0, Size: 36},
+		},
+		{
+			name: "nested_filter",
+			in: &SearchInput{Filter: []*model.InFilter{
+				{BoolTerm: &model.InFilterBoolTerm{Field: "[persons].name.keyword", And: true, Values: []string{"Lilian Frei", "Muda Mathis"}}},
+				{NotTerm: &model.InFilter{BoolTerm: &model.InFilterBoolTerm{Field: "[notes].title.keyword", Values: []string{"Copyright"}}}},
+			}},
+			client: guest,
+			paging: SearchPaging{From: 0, Size: 36},
+		},
+		{
+			name: "nested_facet",
+			in: &SearchInput{Facets: []*model.InFacet{
+				termFacet("[persons].name.keyword", "persons", false, "Muda Mathis"),
+				termFacet("tags.keyword", "tags", false, "performance"),
+			}},
+			client: guest,
+			paging: SearchPaging{From: 0, Size: 36},
+		},
+		{
+			name:   "client",
+			in:     &SearchInput{Query: "festival", First: new(20), Size: new(100)},
+			client: restricted,
+			paging: SearchPaging{From: 20, Size: 10},
+		},
+		{
+			name:   "sort",
+			in:     &SearchInput{Sort: []*model.SortField{{Field: "dateadded", Order: "desc"}, {Field: "signature.keyword", Order: "asc"}}, Explain: true},
+			client: guest,
+			paging: SearchPaging{From: 0, Size: 36},
+		},
+		{
+			name:   "search_before",
+			in:     searchAfter,
+			client: guest,
+			paging: SearchPaging{From: 36, Size: 36},
+		},
+		{
+			// the groups are counted with the post filter of the "or" facet
+			name: "collapse",
+			in: &SearchInput{
+				Query:    "performance",
+				Facets:   []*model.InFacet{termFacet("tags.keyword", "tags", false, "video")},
+				Collapse: &model.InCollapse{Field: "collectiontitle.keyword", InnerHits: 3},
+				First:    new(10),
+				Size:     new(10),
+			},
+			client: guest,
+			paging: SearchPaging{From: 10, Size: 10, Offset: true},
+		},
+		{
+			name:   "vector",
+			in:     &SearchInput{Vector: []float64{0.5, -0.25, 1}, Size: new(5)},
+			client: guest,
+			paging: SearchPaging{From: 0, Size: 5, Offset: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, paging, err := BuildSearchRequest(tt.in, tt.client, []string{"global/guest"}, testSearchOptions)
+			if err != nil {
+				t.Fatalf("BuildSearchRequest() error = %v", err)
+			}
+			if paging.From != tt.paging.From || paging.Size != tt.paging.Size || paging.Offset != tt.paging.Offset {
+				t.Errorf("BuildSearchRequest() paging = %+v, want %+v", *paging, tt.paging)
+			}
+			got, err := json.MarshalIndent(request, "", "  ")
+			if err != nil {
+				t.Fatalf("cannot marshal request: %v", err)
+			}
+			got = append(got, '\n')
+			golden := filepath.Join("testdata", "searchrequest", tt.name+".json")
+			if *updateGolden {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatalf("cannot write %s: %v", golden, err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("cannot read %s: %v", golden, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("BuildSearchRequest() request differs from %s:\n%s", golden, got)
+			}
+		})
+	}
+}
+
+func TestBuildSearchRequestVectorLastPage(t *testing.T) {
+	in := &SearchInput{Vector: []float64{1}, VectorOptions: &model.InVectorOptions{Field: "content_vector", K: 20, NumCandidates: 100}, First: new(15), Size: new(10)}
+	request, paging, err := BuildSearchRequest(in, &config.Client{Name: "guest"}, nil, testSearchOptions)
+	if err != nil {
+		t.Fatalf("BuildSearchRequest() error = %v", err)
+	}
+	// the last page ends with the k nearest neighbours, the cursors keep the page size
+	if *request.Size != 5 || *request.From != 15 || paging.Size != 10 || paging.k != 20 {
+		t.Errorf("BuildSearchRequest() size %d from %d, paging %+v", *request.Size, *request.From, *paging)
+	}
+}
+
+func TestBuildSearchRequestCorrected(t *testing.T) {
+	guest := &config.Client{Name: "guest"}
+	in := &SearchInput{Query: "ocaen", CorrectedQuery: "ocean"}
+	request, paging, err := BuildSearchRequest(in, guest, nil, testSearchOptions)
+	if err != nil {
+		t.Fatalf("BuildSearchRequest() error = %v", err)
+	}
+	if got := request.Query.Bool.Must[0].Bool.Should[0].SimpleQueryString.Query; got != "ocean" {
+		t.Errorf("BuildSearchRequest() query = %s, want corrected query", got)
+	}
+	pageInfo, err := newOffsetPageInfo(100, paging.cursor, "", testSearchOptions.CursorKey)
+	if err != nil {
+		t.Fatalf("newOffsetPageInfo() error = %v", err)
+	}
+	// the next page is requested with the query of the client and continues with the correction
+	next := &SearchInput{Query: "ocaen", Cursor: &pageInfo.EndCursor}
+	request, paging, err = BuildSearchRequest(next, guest, nil, testSearchOptions)
+	if err != nil {
+		t.Fatalf("BuildSearchRequest() next page error = %v", err)
+	}
+	if got := request.Query.Bool.Must[0].Bool.Should[0].SimpleQueryString.Query; got != "ocean" || paging.From != defaultPageSize || paging.cursor.Corrected != "ocean" {
+		t.Errorf("BuildSearchRequest() next page query = %s, paging %+v", got, *paging)
+	}
+}
+
+func TestBuildSearchRequestInvalid(t *testing.T) {
+	guest := &config.Client{Name: "guest"}
+	tests := []struct {
+		name string
+		in   *SearchInput
+	}{
+		{"sort field", &SearchInput{Sort: []*model.SortField{{Field: "title:keyword"}}}},
+		{"vector sort", &SearchInput{Vector: []float64{1}, Sort: []*model.SortField{{Field: "title.keyword"}}}},
+		{"empty filter", &SearchInput{Filter: []*model.InFilter{{}}}},
+		{"search type", &SearchInput{SearchType: "unknown"}},
+		{"cursor", &SearchInput{Cursor: new("invalid")}},
+		{"collapse field", &SearchInput{Collapse: &model.InCollapse{Field: "title"}}},
+		{"collapse multi valued field", &SearchInput{Collapse: &model.InCollapse{Field: "category.keyword"}}},
+		{"collapse inner hits", &SearchInput{Collapse: &model.InCollapse{Field: "series.keyword", InnerHits: 1000}}},
+		{"vector page", &SearchInput{Vector: []float64{1}, VectorOptions: &model.InVectorOptions{Field: "content_vector", K: 20, NumCandidates: 100}, First: new(20), Size: new(10)}},
+		{"vector collapse", &SearchInput{Vector: []float64{1}, Collapse: &model.InCollapse{Field: "series.keyword"}}},
+		{"collapse facet name", &SearchInput{Facets: []*model.InFacet{termFacet("tags.keyword", collapseAggregation, false)}, Collapse: &model.InCollapse{Field: "series.keyword"}}},
+		{"other cursor", &SearchInput{Query: "other", Cursor: testCursor(t, &SearchInput{Query: "ocean"}, guest, NewCursor(36, 36))}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := BuildSearchRequest(tt.in, guest, nil, testSearchOptions); err == nil {
+				t.Errorf("BuildSearchRequest() with invalid %s should fail", tt.name)
+			}
+		})
+	}
+	if _, _, err := BuildSearchRequest(&SearchInput{}, nil, nil, testSearchOptions); err == nil {
+		t.Error("BuildSearchRequest() without client should fail")
+	}
+}
@@ -0,0 +1,115 @@
+package resolver
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/suggestmode"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// suggestFields are the fields of the phrase suggesters, nested fields are written as [path].field
+var suggestFields = []string{"title", "[persons].name", "collectiontitle"}
+
+// suggestShingleField is the subfield of the suggest fields with word shingles, the phrase suggester
+// scores the corrections of multiple words with it
+const suggestShingleField = "shingle"
+
+// collateSuggestion is replaced by the suggestion as json string in the collate template
+const collateSuggestion = "{{suggestion}}"
+
+// newSuggester creates a phrase suggester per suggest field.
+// Corrections are only suggested, if they find a document with the filter applied.
+func newSuggester(query string, filter []types.Query, size int) (*types.Suggester, error) {
+	suggester := &types.Suggester{
+		Suggesters: map[string]types.FieldSuggester{},
+		Text:       new(query),
+	}
+	for _, field := range suggestFields {
+		fieldName := field
+		matches := nestedRegexp.FindStringSubmatch(field)
+		if len(matches) == 3 {
+			fieldName = fmt.Sprintf("%s.%s", matches[1], matches[2])
+		}
+		// the collate query is a mustache template, which is run for every suggestion
+		match := types.Query{Match: map[string]types.MatchQuery{
+			fieldName: {Query: collateSuggestion},
+		}}
+		if len(matches) == 3 {
+			match = types.Query{Nested: &types.NestedQuery{
+				Path:  matches[1],
+				Query: match,
+			}}
+		}
+		collate, err := json.Marshal(&types.Query{Bool: &types.BoolQuery{
+			Filter: append(slices.Clone(filter), match),
+		}})
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot marshal collate query of %s", field)
+		}
+		// the suggestion is rendered as json string, so that quotes and backslashes do not break the query
+		collate = bytes.ReplaceAll(collate, []byte(`"`+collateSuggestion+`"`), []byte("{{#toJson}}suggestion{{/toJson}}"))
+		suggester.Suggesters[fieldName] = types.FieldSuggester{
+			Phrase: &types.PhraseSuggester{
+				Field: fieldName + "." + suggestShingleField,
+				Size:  &size,
+				DirectGenerator: []types.DirectGenerator{{
+					Field:       fieldName,
+					SuggestMode: &suggestmode.Always,
+				}},
+				Collate: &types.PhraseSuggestCollate{
+					Query: types.PhraseSuggestCollateQuery{Source: new(string(collate))},
+				},
+				Highlight: &types.PhraseSuggestHighlight{
+					PreTag:  "<em>",
+					PostTag: "</em>",
+				},
+			},
+		}
+	}
+	return suggester, nil
+}
+
+// suggestions merges the options of all suggesters, the best suggestion comes first.
+// Suggestions equal to the query are dropped.
+func suggestions(query string, suggest map[string][]types.Suggest, size int) []*model.Suggestion {
+	var byText = map[string]*model.Suggestion{}
+	for _, suggests := range suggest {
+		for _, s := range suggests {
+			phrase, ok := s.(*types.PhraseSuggest)
+			if !ok {
+				continue
+			}
+			for _, option := range phrase.Options {
+				if strings.EqualFold(option.Text, query) {
+					continue
+				}
+				if found, ok := byText[option.Text]; ok && found.Score >= float64(option.Score) {
+					continue
+				}
+				byText[option.Text] = &model.Suggestion{
+					Text:        option.Text,
+					Highlighted: option.Highlighted,
+					Score:       float64(option.Score),
+				}
+			}
+		}
+	}
+	var result = []*model.Suggestion{}
+	for _, s := range byText {
+		result = append(result, s)
+	}
+	slices.SortFunc(result, func(a, b *model.Suggestion) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Text, b.Text)
+	})
+	return result[:min(size, len(result))]
+}
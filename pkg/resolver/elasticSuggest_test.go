@@ -0,0 +1,67 @@
+package resolver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/core/search"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+)
+
+func TestNewSuggester(t *testing.T) {
+	filter := []types.Query{{Term: map[string]types.TermQuery{"acl.meta.keyword": {Value: "global/guest"}}}}
+	suggester, err := newSuggester("oceanik", filter, 3)
+	if err != nil {
+		t.Fatalf("newSuggester() error = %v", err)
+	}
+	if len(suggester.Suggesters) != len(suggestFields) {
+		t.Fatalf("newSuggester() = %d suggesters, want %d", len(suggester.Suggesters), len(suggestFields))
+	}
+	persons, ok := suggester.Suggesters["persons.name"]
+	if !ok || persons.Phrase == nil || persons.Phrase.Collate == nil {
+		t.Fatalf("newSuggester() = %+v, want phrase suggester on persons.name", suggester.Suggesters)
+	}
+	collate := *persons.Phrase.Collate.Query.Source
+	for _, want := range []string{`"acl.meta.keyword"`, `"nested"`, `"query":{{#toJson}}suggestion{{/toJson}}`} {
+		if !strings.Contains(collate, want) {
+			t.Errorf("collate query %s does not contain %s", collate, want)
+		}
+	}
+	if persons.Phrase.Field != "persons.name.shingle" || persons.Phrase.DirectGenerator[0].Field != "persons.name" {
+		t.Errorf("newSuggester() fields = %s, %s, want shingles and generator on persons.name", persons.Phrase.Field, persons.Phrase.DirectGenerator[0].Field)
+	}
+	if len(filter) != 1 {
+		t.Errorf("newSuggester() modified the filter %v", filter)
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	resp := search.NewResponse()
+	if err := json.Unmarshal([]byte(`{
+		"hits": {"hits": []},
+		"suggest": {
+			"phrase#title": [{"text": "oceanik isues", "offset": 0, "length": 13, "options": [
+				{"text": "oceanic issues", "highlighted": "<em>oceanic issues</em>", "score": 0.02},
+				{"text": "oceanik isues", "score": 0.01}
+			]}],
+			"phrase#persons.name": [{"text": "oceanik isues", "offset": 0, "length": 13, "options": [
+				{"text": "oceanic issues", "score": 0.03},
+				{"text": "ocean issues", "score": 0.001}
+			]}],
+			"phrase#collectiontitle": [{"text": "oceanik isues", "offset": 0, "length": 13, "options": []}]
+		}
+	}`), resp); err != nil {
+		t.Fatalf("cannot unmarshal response: %v", err)
+	}
+	got := suggestions("oceanik isues", resp.Suggest, 3)
+	if len(got) != 2 {
+		t.Fatalf("suggestions() = %d suggestions, want 2", len(got))
+	}
+	if got[0].Text != "oceanic issues" || got[0].Score != 0.03 || got[1].Text != "ocean issues" {
+		t.Errorf("suggestions() = %+v, %+v", got[0], got[1])
+	}
+	if got := suggestions("oceanik isues", resp.Suggest, 1); len(got) != 1 {
+		t.Errorf("suggestions() = %d suggestions, want 1", len(got))
+	}
+}
@@ -0,0 +1,72 @@
+package resolver
+
+import (
+	"slices"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// vectorFields are the dense_vector fields of the index, which can be used for knn search
+var vectorFields = []string{"content_vector", "title_vector"}
+
+// newKnnSearch creates a native knn search for the query vector.
+// The k nearest neighbours are the whole result, k is raised to size, so that at least one page fits.
+func newKnnSearch(vector []float64, opts *model.InVectorOptions, filter []types.Query, size int) (*types.KnnSearch, error) {
+	if opts == nil {
+		opts = &model.InVectorOptions{
+			Field:         "content_vector",
+			K:             50,
+			NumCandidates: 200,
+		}
+	}
+	if !slices.Contains(vectorFields, opts.Field) {
+		return nil, errors.Errorf("invalid vector field '%s', allowed are %v", opts.Field, vectorFields)
+	}
+	if opts.K <= 0 {
+		return nil, errors.Errorf("invalid k %d", opts.K)
+	}
+	k := max(opts.K, size)
+	// elastic needs at least k candidates per shard
+	numCandidates := min(max(opts.NumCandidates, k), 10000)
+	if k > numCandidates {
+		return nil, errors.Errorf("k %d exceeds the maximum number of candidates %d", k, numCandidates)
+	}
+	queryVector := make([]float32, len(vector))
+	for i, v := range vector {
+		queryVector[i] = float32(v)
+	}
+	knn := &types.KnnSearch{
+		Field:         opts.Field,
+		K:             &k,
+		NumCandidates: &numCandidates,
+		QueryVector:   queryVector,
+		Filter:        filter,
+	}
+	if opts.Similarity != nil {
+		knn.Similarity = new(float32(*opts.Similarity))
+	}
+	return knn, nil
+}
+
+// newHybridRetriever combines the text query and the knn search with reciprocal rank fusion
+func newHybridRetriever(query *types.Query, knn *types.KnnSearch) *types.RetrieverContainer {
+	return &types.RetrieverContainer{
+		Rrf: &types.RRFRetriever{
+			Retrievers: []types.RetrieverContainer{
+				{Standard: &types.StandardRetriever{Query: query}},
+				{Knn: &types.KnnRetriever{
+					Field:         knn.Field,
+					K:             *knn.K,
+					NumCandidates: *knn.NumCandidates,
+					QueryVector:   knn.QueryVector,
+					Similarity:    knn.Similarity,
+					Filter:        knn.Filter,
+				}},
+			},
+			// the fused result is as long as the knn result
+			RankWindowSize: knn.K,
+		},
+	}
+}
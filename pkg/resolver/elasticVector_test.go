@@ -0,0 +1,55 @@
+package resolver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+func TestNewKnnSearch(t *testing.T) {
+	filter := []types.Query{{Exists: &types.ExistsQuery{Field: "poster"}}}
+	knn, err := newKnnSearch([]float64{0.5, 0.25}, nil, filter, 10)
+	if err != nil {
+		t.Fatalf("newKnnSearch() error = %v", err)
+	}
+	if knn.Field != "content_vector" || *knn.K != 50 || *knn.NumCandidates != 200 || len(knn.Filter) != 1 {
+		t.Errorf("newKnnSearch() = %+v", knn)
+	}
+
+	// a page has to fit into the nearest neighbours
+	similarity := 0.8
+	knn, err = newKnnSearch([]float64{0.5}, &model.InVectorOptions{Field: "title_vector", K: 10, NumCandidates: 20, Similarity: &similarity}, nil, 72)
+	if err != nil {
+		t.Fatalf("newKnnSearch() error = %v", err)
+	}
+	if *knn.K != 72 || *knn.NumCandidates != 72 || knn.Similarity == nil || *knn.Similarity != 0.8 {
+		t.Errorf("newKnnSearch() = k %d, candidates %d, similarity %v", *knn.K, *knn.NumCandidates, knn.Similarity)
+	}
+
+	if _, err := newKnnSearch([]float64{0.5}, &model.InVectorOptions{Field: "title", K: 10, NumCandidates: 20}, nil, 10); err == nil {
+		t.Errorf("newKnnSearch() with field without vectors should fail")
+	}
+	if _, err := newKnnSearch([]float64{0.5}, &model.InVectorOptions{Field: "content_vector", K: 0, NumCandidates: 20}, nil, 10); err == nil {
+		t.Errorf("newKnnSearch() with k 0 should fail")
+	}
+}
+
+func TestNewHybridRetriever(t *testing.T) {
+	knn, err := newKnnSearch([]float64{0.5}, nil, nil, 36)
+	if err != nil {
+		t.Fatalf("newKnnSearch() error = %v", err)
+	}
+	retriever := newHybridRetriever(&types.Query{MatchAll: types.NewMatchAllQuery()}, knn)
+	data, err := json.Marshal(retriever)
+	if err != nil {
+		t.Fatalf("cannot marshal retriever: %v", err)
+	}
+	for _, want := range []string{`"rrf":`, `"standard":{"query":{"match_all":{}}}`, `"knn":{"field":"content_vector"`, `"rank_window_size":50`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("retriever %s does not contain %s", data, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package resolver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/bluele/gcache"
+)
+
+// Embedder computes the vector of a text, which is used for semantic search
+type Embedder interface {
+	Embed(ctx context.Context, text string) ([]float64, error)
+}
+
+// NewHTTPEmbedder creates an embedder for an OpenAI compatible embeddings endpoint
+func NewHTTPEmbedder(endpoint, embeddingModel, apiKey string, timeout time.Duration) Embedder {
+	return &httpEmbedder{
+		endpoint: endpoint,
+		model:    embeddingModel,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+type httpEmbedder struct {
+	endpoint string
+	model    string
+	apiKey   string
+	client   *http.Client
+}
+
+type embeddingRequest struct {
+	Input string `json:"input"`
+	Model string `json:"model,omitempty"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (e *httpEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	reqBody, err := json.Marshal(&embeddingRequest{Input: text, Model: e.model})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot marshal embedding request")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot create request for '%s'", e.endpoint)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", e.apiKey))
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot query embedding endpoint '%s'", e.endpoint)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("embedding endpoint '%s' returned %s: %s", e.endpoint, resp.Status, string(body))
+	}
+	result := &embeddingResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, errors.Wrap(err, "cannot decode embedding response")
+	}
+	if len(result.Data) == 0 || len(result.Data[0].Embedding) == 0 {
+		return nil, errors.Errorf("no embedding for '%s' returned", text)
+	}
+	return result.Data[0].Embedding, nil
+}
+
+var _ Embedder = (*httpEmbedder)(nil)
+
+// NewCachedEmbedder keeps the vectors of the last cacheSize texts
+func NewCachedEmbedder(embedder Embedder, cacheSize int) Embedder {
+	return &cachedEmbedder{
+		embedder: embedder,
+		cache:    gcache.New(cacheSize).LRU().Build(),
+	}
+}
+
+type cachedEmbedder struct {
+	embedder Embedder
+	cache    gcache.Cache
+}
+
+func (c *cachedEmbedder) Embed(ctx context.Context, text string) ([]float64, error) {
+	if obj, err := c.cache.Get(text); err == nil {
+		if vector, ok := obj.([]float64); ok {
+			return vector, nil
+		}
+	}
+	vector, err := c.embedder.Embed(ctx, text)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	c.cache.Set(text, vector)
+	return vector, nil
+}
+
+var _ Embedder = (*cachedEmbedder)(nil)
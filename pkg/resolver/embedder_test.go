@@ -0,0 +1,67 @@
+package resolver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newEmbeddingTestServer(t *testing.T, calls *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		req := &embeddingRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Model != "test-model" {
+			t.Errorf("model = %s, want test-model", req.Model)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{{"embedding": []float64{float64(len(req.Input)), 0.5}}},
+		})
+	}))
+}
+
+func TestHTTPEmbedder(t *testing.T) {
+	var calls int
+	srv := newEmbeddingTestServer(t, &calls)
+	defer srv.Close()
+
+	embedder := NewHTTPEmbedder(srv.URL, "test-model", "secret", time.Second)
+	vector, err := embedder.Embed(context.Background(), "ocean")
+	if err != nil {
+		t.Fatalf("Embed() error = %v", err)
+	}
+	if len(vector) != 2 || vector[0] != 5 || vector[1] != 0.5 {
+		t.Errorf("Embed() = %v, want [5 0.5]", vector)
+	}
+
+	if _, err := NewHTTPEmbedder(srv.URL, "test-model", "wrong", time.Second).Embed(context.Background(), "ocean"); err == nil {
+		t.Errorf("Embed() with wrong api key should fail")
+	}
+}
+
+func TestCachedEmbedder(t *testing.T) {
+	var calls int
+	srv := newEmbeddingTestServer(t, &calls)
+	defer srv.Close()
+
+	embedder := NewCachedEmbedder(NewHTTPEmbedder(srv.URL, "test-model", "secret", time.Second), 10)
+	for _, text := range []string{"ocean", "motet", "ocean"} {
+		if _, err := embedder.Embed(context.Background(), text); err != nil {
+			t.Fatalf("Embed(%s) error = %v", text, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("embedding endpoint called %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,88 @@
+package resolver
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/je4/revcat/v2/pkg/sourcetype"
+)
+
+// entryLoadWait is the time the entry loader collects signatures before loading them
+const entryLoadWait = 2 * time.Millisecond
+
+// entryBatch is a load of entries, which is shared by all callers waiting for it
+type entryBatch struct {
+	ctx        context.Context
+	signatures []string
+	done       chan struct{}
+	entries    map[string]sourcetype.SourceData
+	err        error
+}
+
+// entryLoader combines the concurrent loads of single entries, like the fulltexts of the media
+// of a search result, into one request. Every signature is only loaded once per batch.
+type entryLoader struct {
+	load  func(ctx context.Context, signatures []string) ([]sourcetype.SourceData, error)
+	wait  time.Duration
+	lock  sync.Mutex
+	batch *entryBatch
+}
+
+func newEntryLoader(load func(ctx context.Context, signatures []string) ([]sourcetype.SourceData, error), wait time.Duration) *entryLoader {
+	return &entryLoader{
+		load: load,
+		wait: wait,
+	}
+}
+
+// Load returns the entry of the signature, it is loaded with the entries requested in the same time
+func (l *entryLoader) Load(ctx context.Context, signature string) (*sourcetype.SourceData, error) {
+	l.lock.Lock()
+	batch := l.batch
+	if batch == nil {
+		// the batch must not be canceled with the request, which started it
+		batch = &entryBatch{ctx: context.WithoutCancel(ctx), done: make(chan struct{})}
+		l.batch = batch
+		time.AfterFunc(l.wait, func() { l.run(batch) })
+	}
+	if !slices.Contains(batch.signatures, signature) {
+		batch.signatures = append(batch.signatures, signature)
+	}
+	l.lock.Unlock()
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return nil, errors.WithStack(ctx.Err())
+	}
+	if batch.err != nil {
+		return nil, errors.WithStack(batch.err)
+	}
+	entry, ok := batch.entries[signature]
+	if !ok {
+		return nil, errors.Errorf("entry %s not found", signature)
+	}
+	return &entry, nil
+}
+
+func (l *entryLoader) run(batch *entryBatch) {
+	l.lock.Lock()
+	if l.batch == batch {
+		l.batch = nil
+	}
+	l.lock.Unlock()
+
+	defer close(batch.done)
+	entries, err := l.load(batch.ctx, batch.signatures)
+	if err != nil {
+		batch.err = errors.Wrapf(err, "cannot load entries %v", batch.signatures)
+		return
+	}
+	batch.entries = make(map[string]sourcetype.SourceData, len(entries))
+	for _, entry := range entries {
+		batch.entries[entry.ID] = entry
+	}
+}
@@ -0,0 +1,54 @@
+package resolver
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/je4/revcat/v2/pkg/sourcetype"
+)
+
+func TestEntryLoader(t *testing.T) {
+	var lock sync.Mutex
+	var loads [][]string
+	loader := newEntryLoader(func(ctx context.Context, signatures []string) ([]sourcetype.SourceData, error) {
+		lock.Lock()
+		loads = append(loads, slices.Clone(signatures))
+		lock.Unlock()
+		var result = []sourcetype.SourceData{}
+		for _, signature := range signatures {
+			if signature != "missing" {
+				result = append(result, sourcetype.SourceData{ID: signature, CollectionTitle: "collection of " + signature})
+			}
+		}
+		return result, nil
+	}, 20*time.Millisecond)
+
+	signatures := []string{"a", "b", "a", "c", "b", "a"}
+	var wg sync.WaitGroup
+	for _, signature := range signatures {
+		wg.Go(func() {
+			entry, err := loader.Load(context.Background(), signature)
+			if err != nil {
+				t.Errorf("Load(%s) error = %v", signature, err)
+				return
+			}
+			if entry.CollectionTitle != "collection of "+signature {
+				t.Errorf("Load(%s) = %s", signature, entry.CollectionTitle)
+			}
+		})
+	}
+	wg.Wait()
+	if len(loads) != 1 || len(loads[0]) != 3 {
+		t.Errorf("Load() = %v, want one load of three signatures", loads)
+	}
+
+	if _, err := loader.Load(context.Background(), "missing"); err == nil {
+		t.Errorf("Load() of missing entry should fail")
+	}
+	if len(loads) != 2 {
+		t.Errorf("Load() after the batch = %d loads, want a new batch", len(loads))
+	}
+}
@@ -2,16 +2,23 @@ package resolver
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"emperror.dev/errors"
+	"github.com/99designs/gqlgen/graphql/errcode"
 	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/je4/revcat/v2/config"
 	"github.com/je4/revcat/v2/pkg/sourcetype"
 	"github.com/je4/revcat/v2/tools/graph/model"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
 func CheckJWTValid(tokenstring string, secret string, alg []string, maxAge time.Duration) (map[string]interface{}, error) {
@@ -56,8 +63,147 @@ func CheckJWTValid(tokenstring string, secret string, alg []string, maxAge time.
 
 var nestedRegexp = regexp.MustCompile(`^\[([^\[\]]+)\]\.(.*)$`)
 
-func createFilterQuery(filter *model.InFilter) (*types.Query, error) {
+// newValidationError creates a graphql validation error, which is returned to the client unchanged
+func newValidationError(format string, args ...any) error {
+	err := gqlerror.Errorf(format, args...)
+	errcode.Set(err, errcode.ValidationFailed)
+	return err
+}
+
+// validateFilter checks, that the filter has exactly one term and the term has a condition
+func validateFilter(filter *model.InFilter) error {
+	if filter == nil {
+		return newValidationError("empty filter")
+	}
+	var terms = []string{}
+	var field string
+	var empty bool
+	if filter.BoolTerm != nil {
+		terms = append(terms, "boolTerm")
+		field, empty = filter.BoolTerm.Field, len(filter.BoolTerm.Values) == 0
+	}
 	if filter.ExistsTerm != nil {
+		terms = append(terms, "existsTerm")
+		field = filter.ExistsTerm.Field
+	}
+	if filter.RangeTerm != nil {
+		terms = append(terms, "rangeTerm")
+		field, empty = filter.RangeTerm.Field, filter.RangeTerm.From == nil && filter.RangeTerm.To == nil
+	}
+	if filter.HierarchyTerm != nil {
+		terms = append(terms, "hierarchyTerm")
+		field, empty = filter.HierarchyTerm.Field, len(filter.HierarchyTerm.Values) == 0
+	}
+	if filter.PrefixTerm != nil {
+		terms = append(terms, "prefixTerm")
+		field, empty = filter.PrefixTerm.Field, len(filter.PrefixTerm.Values) == 0
+	}
+	if filter.WildcardTerm != nil {
+		terms = append(terms, "wildcardTerm")
+		field, empty = filter.WildcardTerm.Field, len(filter.WildcardTerm.Values) == 0
+	}
+	if filter.NotTerm != nil {
+		terms = append(terms, "notTerm")
+	}
+	switch len(terms) {
+	case 0:
+		return newValidationError("filter without term")
+	case 1:
+	default:
+		return newValidationError("filter with more than one term: %s", strings.Join(terms, ", "))
+	}
+	if filter.NotTerm != nil {
+		return validateFilter(filter.NotTerm)
+	}
+	if field == "" {
+		return newValidationError("%s without field", terms[0])
+	}
+	if empty {
+		return newValidationError("%s on '%s' without values", terms[0], field)
+	}
+	return nil
+}
+
+// facetFilter returns the query of the facet, facets without query or selected values do not filter
+func facetFilter(facet *model.InFacet) *model.InFilter {
+	query := facet.Query
+	switch {
+	case query == nil:
+		return nil
+	case query.BoolTerm != nil:
+		if len(query.BoolTerm.Values) == 0 {
+			return nil
+		}
+	case query.HierarchyTerm != nil:
+		if len(query.HierarchyTerm.Values) == 0 {
+			return nil
+		}
+	case query.RangeTerm != nil:
+		if query.RangeTerm.From == nil && query.RangeTerm.To == nil {
+			return nil
+		}
+	case query.PrefixTerm != nil:
+		if len(query.PrefixTerm.Values) == 0 {
+			return nil
+		}
+	case query.WildcardTerm != nil:
+		if len(query.WildcardTerm.Values) == 0 {
+			return nil
+		}
+	case query.ExistsTerm == nil && query.NotTerm == nil:
+		return nil
+	}
+	return query
+}
+
+// nestedFilterQuery creates a query with one sub query per value, which match if one of the values matches.
+// Fields of nested documents are written as [path].field
+func nestedFilterQuery(field string, values []string, query func(fieldName, value string) types.Query) *types.Query {
+	fieldName := field
+	matches := nestedRegexp.FindStringSubmatch(field)
+	if len(matches) == 3 {
+		fieldName = fmt.Sprintf("%s.%s", matches[1], matches[2])
+	}
+	var result = &types.Query{Bool: &types.BoolQuery{MinimumShouldMatch: 1}}
+	for _, val := range values {
+		result.Bool.Should = append(result.Bool.Should, query(fieldName, val))
+	}
+	if len(matches) == 3 {
+		return &types.Query{Nested: &types.NestedQuery{
+			Path:  matches[1],
+			Query: *result,
+		}}
+	}
+	return result
+}
+
+func createFilterQuery(filter *model.InFilter) (*types.Query, error) {
+	if err := validateFilter(filter); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if filter.NotTerm != nil {
+		query, err := createFilterQuery(filter.NotTerm)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid notTerm")
+		}
+		return &types.Query{Bool: &types.BoolQuery{MustNot: []types.Query{*query}}}, nil
+	} else if filter.PrefixTerm != nil {
+		return nestedFilterQuery(filter.PrefixTerm.Field, filter.PrefixTerm.Values, func(fieldName, value string) types.Query {
+			return types.Query{Prefix: map[string]types.PrefixQuery{
+				fieldName: {Value: value},
+			}}
+		}), nil
+	} else if filter.WildcardTerm != nil {
+		return nestedFilterQuery(filter.WildcardTerm.Field, filter.WildcardTerm.Values, func(fieldName, value string) types.Query {
+			wildcard := types.WildcardQuery{Value: new(value)}
+			if filter.WildcardTerm.CaseInsensitive {
+				wildcard.CaseInsensitive = new(true)
+			}
+			return types.Query{Wildcard: map[string]types.WildcardQuery{
+				fieldName: wildcard,
+			}}
+		}), nil
+	} else if filter.ExistsTerm != nil {
 		var query = &types.Query{Exists: &types.ExistsQuery{
 			Field: filter.ExistsTerm.Field,
 		}}
@@ -129,9 +275,64 @@ func createFilterQuery(filter *model.InFilter) (*types.Query, error) {
 			}
 		}
 		return query, nil
+	} else if filter.RangeTerm != nil && (filter.RangeTerm.From != nil || filter.RangeTerm.To != nil) {
+		// the bounds are passed as strings, elastic parses them as number or date of the field
+		// from is inclusive and to is exclusive, unless specified otherwise
+		rangeQuery := types.UntypedRangeQuery{}
+		if filter.RangeTerm.From != nil {
+			from := json.RawMessage(strconv.Quote(*filter.RangeTerm.From))
+			if filter.RangeTerm.IncludeFrom == nil || *filter.RangeTerm.IncludeFrom {
+				rangeQuery.Gte = from
+			} else {
+				rangeQuery.Gt = from
+			}
+		}
+		if filter.RangeTerm.To != nil {
+			to := json.RawMessage(strconv.Quote(*filter.RangeTerm.To))
+			if filter.RangeTerm.IncludeTo != nil && *filter.RangeTerm.IncludeTo {
+				rangeQuery.Lte = to
+			} else {
+				rangeQuery.Lt = to
+			}
+		}
+		if matches := nestedRegexp.FindStringSubmatch(filter.RangeTerm.Field); len(matches) == 3 {
+			return &types.Query{Nested: &types.NestedQuery{
+				Path: matches[1],
+				Query: types.Query{Range: map[string]types.RangeQuery{
+					fmt.Sprintf("%s.%s", matches[1], matches[2]): rangeQuery,
+				}},
+			}}, nil
+		}
+		return &types.Query{Range: map[string]types.RangeQuery{
+			filter.RangeTerm.Field: rangeQuery,
+		}}, nil
+	} else if filter.HierarchyTerm != nil && len(filter.HierarchyTerm.Values) > 0 {
+		// a node of the hierarchy selects itself and all its descendants
+		var query = &types.Query{Bool: &types.BoolQuery{MinimumShouldMatch: 1}}
+		fieldName := filter.HierarchyTerm.Field
+		matches := nestedRegexp.FindStringSubmatch(fieldName)
+		if len(matches) == 3 {
+			fieldName = fmt.Sprintf("%s.%s", matches[1], matches[2])
+		}
+		for _, val := range filter.HierarchyTerm.Values {
+			query.Bool.Should = append(query.Bool.Should,
+				types.Query{Term: map[string]types.TermQuery{
+					fieldName: {Value: val},
+				}},
+				types.Query{Prefix: map[string]types.PrefixQuery{
+					fieldName: {Value: val + filter.HierarchyTerm.Separator},
+				}},
+			)
+		}
+		if len(matches) == 3 {
+			return &types.Query{Nested: &types.NestedQuery{
+				Path:  matches[1],
+				Query: *query,
+			}}, nil
+		}
+		return query, nil
 	}
-	//	return types.Query{}, errors.Errorf("unknown filter type")
-	return nil, nil
+	return nil, errors.WithStack(newValidationError("unknown filter type"))
 }
 
 func stringsFromContext(ctx context.Context, key string) ([]string, error) {
@@ -175,6 +376,33 @@ func ginContextFromContext(ctx context.Context) (*gin.Context, error) {
 	return gc, nil
 }
 
+// aclAccess returns the acl types granted to the groups and whether the media content is protected
+func aclAccess(acl map[string][]string, groups []string) (access map[string]bool, mediaProtected bool) {
+	access = make(map[string]bool)
+	for t, acls := range acl {
+		t = strings.ToLower(t)
+		if t == "content" {
+			mediaProtected = !slices.Contains(acls, "global/guest")
+		}
+		for _, group := range groups {
+			if slices.Contains(acls, group) {
+				access[t] = true
+				break
+			}
+		}
+	}
+	return access, mediaProtected
+}
+
+// clientPageSize limits the page size to the maximum page size of the client, if configured
+func clientPageSize(client *config.Client, num int) int {
+	if client != nil && client.MaxPageSize > 0 {
+		return min(num, client.MaxPageSize)
+	}
+	return num
+}
+
+// sourceMediaToMedia converts the media, the fulltext is nil if it is empty or not part of the source
 func sourceMediaToMedia(m *sourcetype.Media) *model.Media {
 	if m == nil {
 		return nil
@@ -186,14 +414,41 @@ func sourceMediaToMedia(m *sourcetype.Media) *model.Media {
 		Type:        m.Type,
 		URI:         m.Uri,
 		Orientation: int(m.Orientation),
-		Fulltext:    &m.Fulltext,
 		Width:       int(m.Width),
 		Height:      int(m.Height),
 		Length:      int(m.Duration),
 	}
+	if m.Fulltext != "" {
+		media.Fulltext = new(m.Fulltext)
+	}
 	return media
 }
 
+// sourceMediaToMediaList converts the media of the entry, the fulltext is only returned if the media is visible.
+// Visible media reference the entry, so that a fulltext missing in search results can be loaded.
+func sourceMediaToMediaList(signature string, media map[string]sourcetype.MediaList, mediaVisible bool) []*model.MediaList {
+	var result = []*model.MediaList{}
+	for key, ml := range media {
+		mediaList := &model.MediaList{
+			Type:  key,
+			Items: make([]*model.Media, 0),
+		}
+		for index, mediaItem := range ml {
+			item := sourceMediaToMedia(&mediaItem)
+			if mediaVisible {
+				item.Signature = signature
+				item.List = key
+				item.Index = index
+			} else {
+				item.Fulltext = nil
+			}
+			mediaList.Items = append(mediaList.Items, item)
+		}
+		result = append(result, mediaList)
+	}
+	return result
+}
+
 func sourceToMediathekBaseEntry(src *sourcetype.SourceData) *model.MediathekBaseEntry {
 	collectionTitle := src.GetCollectionTitle()
 	series := src.GetSeries()
@@ -227,6 +482,11 @@ func sourceToMediathekBaseEntry(src *sourcetype.SourceData) *model.MediathekBase
 		Poster:            sourceMediaToMedia(src.GetPoster()),
 		ACL:               make([]*model.ACL, 0),
 	}
+	if date, err := src.GetDateInterval(); err == nil {
+		entry.DateStart = new(date.Start.Format(time.DateOnly))
+		entry.DateEnd = new(date.End.Format(time.DateOnly))
+		entry.DatePrecision = new(string(date.Precision))
+	}
 	for name, acls := range src.GetACL() {
 		entry.ACL = append(entry.ACL, &model.ACL{
 			Name:   name,
@@ -0,0 +1,106 @@
+package resolver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/je4/revcat/v2/pkg/sourcetype"
+	"github.com/je4/revcat/v2/tools/graph/model"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+func TestCreateFilterQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *model.InFilter
+		want   string
+	}{
+		{
+			name:   "range with exclusive from and inclusive to",
+			filter: &model.InFilter{RangeTerm: &model.InFilterRangeTerm{Field: "date", From: new("1990"), To: new("1999"), IncludeFrom: new(false), IncludeTo: new(true)}},
+			want:   `{"range":{"date":{"gt":"1990","lte":"1999"}}}`,
+		},
+		{
+			name:   "not",
+			filter: &model.InFilter{NotTerm: &model.InFilter{BoolTerm: &model.InFilterBoolTerm{Field: "category.keyword", Values: []string{"zotero2!!Konvolute"}}}},
+			want:   `{"bool":{"must_not":[{"bool":{"minimum_should_match":1,"should":[{"term":{"category.keyword":{"value":"zotero2!!Konvolute"}}}]}}]}}`,
+		},
+		{
+			name:   "prefix",
+			filter: &model.InFilter{PrefixTerm: &model.InFilterPrefixTerm{Field: "signature.keyword", Values: []string{"zotero2-2486551", "zotero2-2486552"}}},
+			want:   `{"bool":{"minimum_should_match":1,"should":[{"prefix":{"signature.keyword":{"value":"zotero2-2486551"}}},{"prefix":{"signature.keyword":{"value":"zotero2-2486552"}}}]}}`,
+		},
+		{
+			name:   "nested wildcard",
+			filter: &model.InFilter{WildcardTerm: &model.InFilterWildcardTerm{Field: "[persons].name.keyword", CaseInsensitive: true, Values: []string{"ocean*"}}},
+			want:   `{"nested":{"path":"persons","query":{"bool":{"minimum_should_match":1,"should":[{"wildcard":{"persons.name.keyword":{"case_insensitive":true,"value":"ocean*"}}}]}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := createFilterQuery(tt.filter)
+			if err != nil {
+				t.Fatalf("createFilterQuery() error = %v", err)
+			}
+			data, err := json.Marshal(query)
+			if err != nil {
+				t.Fatalf("cannot marshal query: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("createFilterQuery() = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateFilterQueryInvalid(t *testing.T) {
+	for name, filter := range map[string]*model.InFilter{
+		"no term":              {},
+		"empty values":         {BoolTerm: &model.InFilterBoolTerm{Field: "tags.keyword"}},
+		"two terms":            {ExistsTerm: &model.InFilterExistsTerm{Field: "abstract"}, PrefixTerm: &model.InFilterPrefixTerm{Field: "signature.keyword", Values: []string{"zotero2"}}},
+		"range without bounds": {RangeTerm: &model.InFilterRangeTerm{Field: "date"}},
+		"empty not":            {NotTerm: &model.InFilter{}},
+	} {
+		_, err := createFilterQuery(filter)
+		var gqlErr *gqlerror.Error
+		if !errors.As(err, &gqlErr) || gqlErr.Extensions["code"] != errcode.ValidationFailed {
+			t.Errorf("createFilterQuery() with %s error = %v, want validation error", name, err)
+		}
+	}
+}
+
+func TestFacetFilter(t *testing.T) {
+	if query := facetFilter(&model.InFacet{Query: &model.InFilter{}}); query != nil {
+		t.Errorf("facetFilter() without term = %v, want nil", query)
+	}
+	if query := facetFilter(&model.InFacet{Query: &model.InFilter{BoolTerm: &model.InFilterBoolTerm{Field: "tags.keyword"}}}); query != nil {
+		t.Errorf("facetFilter() without values = %v, want nil", query)
+	}
+	if query := facetFilter(&model.InFacet{Query: &model.InFilter{ExistsTerm: &model.InFilterExistsTerm{Field: "abstract"}}}); query == nil {
+		t.Errorf("facetFilter() with exists term = nil")
+	}
+}
+
+func TestSourceMediaToMediaList(t *testing.T) {
+	media := map[string]sourcetype.MediaList{"pdf": {
+		{Name: "cover", Type: "pdf"},
+		{Name: "text", Type: "pdf", Fulltext: "Grüße aus Basel"},
+	}}
+	lists := sourceMediaToMediaList("zotero-1", media, true)
+	if len(lists) != 1 || len(lists[0].Items) != 2 {
+		t.Fatalf("sourceMediaToMediaList() = %v, want one list with two items", lists)
+	}
+	item := lists[0].Items[1]
+	if item.Fulltext == nil || item.Signature != "zotero-1" || item.List != "pdf" || item.Index != 1 {
+		t.Errorf("sourceMediaToMediaList() item = %+v, want fulltext and reference to the entry", item)
+	}
+	if lists[0].Items[0].Fulltext != nil {
+		t.Errorf("sourceMediaToMediaList() empty fulltext = %q, want nil", *lists[0].Items[0].Fulltext)
+	}
+	item = sourceMediaToMediaList("zotero-1", media, false)[0].Items[1]
+	if item.Fulltext != nil || item.Signature != "" {
+		t.Errorf("sourceMediaToMediaList() invisible item = %+v, want no fulltext and no reference", item)
+	}
+}
@@ -0,0 +1,157 @@
+package resolver
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"emperror.dev/errors"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// fulltextPage returns length characters of the fulltext starting at offset, all remaining characters without length
+func fulltextPage(fulltext *string, offset int, length *int) (*string, error) {
+	if offset < 0 {
+		return nil, errors.Errorf("invalid fulltext offset %d", offset)
+	}
+	if length != nil && *length < 0 {
+		return nil, errors.Errorf("invalid fulltext length %d", *length)
+	}
+	if fulltext == nil {
+		return nil, nil
+	}
+	runes := []rune(*fulltext)
+	start := min(offset, len(runes))
+	end := len(runes)
+	if length != nil {
+		end = min(start+*length, len(runes))
+	}
+	return new(string(runes[start:end])), nil
+}
+
+// fulltextRange is the range of a match in a fulltext as character offsets, end is excluding
+type fulltextRange struct {
+	start, end int
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// fulltextPositions returns the ranges of all whole word occurrences of the terms in the text, ignoring case
+func fulltextPositions(text string, terms []string) []fulltextRange {
+	lower := []rune(text)
+	for i, r := range lower {
+		lower[i] = unicode.ToLower(r)
+	}
+	var result = []fulltextRange{}
+	for _, term := range terms {
+		termRunes := []rune(strings.ToLower(term))
+		if len(termRunes) == 0 {
+			continue
+		}
+		for i := 0; i+len(termRunes) <= len(lower); i++ {
+			if !slices.Equal(lower[i:i+len(termRunes)], termRunes) {
+				continue
+			}
+			if i > 0 && isWordRune(lower[i-1]) {
+				continue
+			}
+			end := i + len(termRunes)
+			if end < len(lower) && isWordRune(lower[end]) {
+				continue
+			}
+			result = append(result, fulltextRange{start: i, end: end})
+		}
+	}
+	slices.SortFunc(result, func(a, b fulltextRange) int {
+		return cmp.Or(cmp.Compare(a.start, b.start), cmp.Compare(a.end, b.end))
+	})
+	return slices.Compact(result)
+}
+
+var highlightTermRegexp = regexp.MustCompile(`<em>(.*?)</em>`)
+
+// highlightedTerms returns the terms marked by the highlighter in the fragments
+func highlightedTerms(fragments []string) []string {
+	var result = []string{}
+	for _, fragment := range fragments {
+		for _, matches := range highlightTermRegexp.FindAllStringSubmatch(fragment, -1) {
+			if !slices.Contains(result, matches[1]) {
+				result = append(result, matches[1])
+			}
+		}
+	}
+	return result
+}
+
+// fulltextSnippets creates up to fragments snippets of fragmentSize characters around the matches with the matches highlighted
+func fulltextSnippets(text string, positions []fulltextRange, fragmentSize, fragments int) []string {
+	runes := []rune(text)
+	var result = []string{}
+	var covered = 0
+	for _, pos := range positions {
+		if len(result) >= fragments {
+			break
+		}
+		if pos.start < covered {
+			continue
+		}
+		start := max(0, pos.start-max(0, fragmentSize-(pos.end-pos.start))/2)
+		end := min(len(runes), max(start+fragmentSize, pos.end))
+		var sb strings.Builder
+		last := start
+		for _, p := range positions {
+			if p.start < last || p.end > end {
+				continue
+			}
+			sb.WriteString(string(runes[last:p.start]))
+			sb.WriteString("<em>")
+			sb.WriteString(string(runes[p.start:p.end]))
+			sb.WriteString("</em>")
+			last = p.end
+		}
+		sb.WriteString(string(runes[last:end]))
+		result = append(result, strings.TrimSpace(sb.String()))
+		covered = end
+	}
+	return result
+}
+
+// newMediaMatch creates the match of a media item, the positions are the start offsets of the matches
+func newMediaMatch(mediaType string, index int, media *model.Media, score *float64, snippets []string, positions []fulltextRange) *model.MediaMatch {
+	match := &model.MediaMatch{
+		Type:      mediaType,
+		Index:     index,
+		Media:     media,
+		Score:     score,
+		Snippets:  snippets,
+		Positions: []int{},
+	}
+	if match.Snippets == nil {
+		match.Snippets = []string{}
+	}
+	media.Matched = true
+	media.Score = score
+	media.Snippets = match.Snippets
+	for _, pos := range positions {
+		match.Positions = append(match.Positions, pos.start)
+	}
+	return match
+}
+
+// sortMediaMatches orders the matches by score and position in the entry
+func sortMediaMatches(matches []*model.MediaMatch) {
+	slices.SortFunc(matches, func(a, b *model.MediaMatch) int {
+		var scoreA, scoreB float64
+		if a.Score != nil {
+			scoreA = *a.Score
+		}
+		if b.Score != nil {
+			scoreB = *b.Score
+		}
+		return cmp.Or(cmp.Compare(scoreB, scoreA), strings.Compare(a.Type, b.Type), cmp.Compare(a.Index, b.Index))
+	})
+}
@@ -0,0 +1,63 @@
+package resolver
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+func TestFulltextPage(t *testing.T) {
+	media := &model.Media{Fulltext: new("Grüße aus Basel")}
+	for _, tt := range []struct {
+		offset int
+		length *int
+		want   string
+	}{
+		{0, new(5), "Grüße"},
+		{10, new(100), "Basel"},
+		{100, new(10), ""},
+		{6, nil, "aus Basel"},
+	} {
+		page, err := fulltextPage(media.Fulltext, tt.offset, tt.length)
+		if err != nil {
+			t.Fatalf("fulltextPage() error = %v", err)
+		}
+		if *page != tt.want {
+			t.Errorf("fulltextPage(%d, %v) = %q, want %q", tt.offset, tt.length, *page, tt.want)
+		}
+	}
+	if _, err := fulltextPage(media.Fulltext, -1, new(10)); err == nil {
+		t.Errorf("fulltextPage() with negative offset should fail")
+	}
+	if _, err := fulltextPage(media.Fulltext, 0, new(-1)); err == nil {
+		t.Errorf("fulltextPage() with negative length should fail")
+	}
+	if page, err := fulltextPage(nil, 0, nil); err != nil || page != nil {
+		t.Errorf("fulltextPage() without fulltext = %v, %v", page, err)
+	}
+}
+
+func TestFulltextPositions(t *testing.T) {
+	positions := fulltextPositions("Ozean, Ozeane und der OZEAN", []string{"ozean"})
+	want := []fulltextRange{{start: 0, end: 5}, {start: 22, end: 27}}
+	if !slices.Equal(positions, want) {
+		t.Errorf("fulltextPositions() = %v, want %v", positions, want)
+	}
+}
+
+func TestHighlightedTerms(t *testing.T) {
+	terms := highlightedTerms([]string{"der <em>Ozean</em> und <em>Wellen</em>", "am <em>Ozean</em>"})
+	if !slices.Equal(terms, []string{"Ozean", "Wellen"}) {
+		t.Errorf("highlightedTerms() = %v", terms)
+	}
+}
+
+func TestFulltextSnippets(t *testing.T) {
+	text := "aaaa Ozean bbbb cccc dddd eeee Ozean ffff"
+	snippets := fulltextSnippets(text, fulltextPositions(text, []string{"ozean"}), 15, 3)
+	want := []string{"aaaa <em>Ozean</em> bbbb", "eeee <em>Ozean</em> ffff"}
+	if !slices.Equal(snippets, want) {
+		t.Errorf("fulltextSnippets() = %q, want %q", snippets, want)
+	}
+}
@@ -8,10 +8,31 @@ import (
 
 type Resolver interface {
 	// Search is the resolver for the search field.
-	Search(ctx context.Context, searchType string, query string, facets []*model.InFacet, filter []*model.InFilter, vector []float64, first *int, size *int, cursor *string, sort []*model.SortField) (*model.SearchResult, error)
+	Search(ctx context.Context, searchType string, query string, advancedQuery *model.InAdvancedQuery, facets []*model.InFacet, filter []*model.InFilter, vector []float64, vectorOptions *model.InVectorOptions, first *int, size *int, cursor *string, sort []*model.SortField, autoCorrect bool, explain bool, collapse *model.InCollapse) (*model.SearchResult, error)
+
+	// SearchRequest is the resolver for the searchRequest field.
+	SearchRequest(ctx context.Context, searchType string, query string, advancedQuery *model.InAdvancedQuery, facets []*model.InFacet, filter []*model.InFilter, vector []float64, vectorOptions *model.InVectorOptions, first *int, size *int, cursor *string, sort []*model.SortField, collapse *model.InCollapse) (string, error)
 
 	// MediathekEntries is the resolver for the mediathekEntries field.
 	MediathekEntries(ctx context.Context, signatures []string) ([]*model.MediathekFullEntry, error)
 
 	ReferencesFull(ctx context.Context, obj *model.MediathekFullEntry) ([]*model.MediathekBaseEntry, error)
+
+	// ThemaTree is the resolver for the themaTree field.
+	ThemaTree(ctx context.Context, filter []*model.InFilter) ([]*model.ThemaNode, error)
+
+	// Autocomplete is the resolver for the autocomplete field.
+	Autocomplete(ctx context.Context, prefix string, fields []string, size int) ([]*model.AutocompleteSuggestion, error)
+
+	// Related is the resolver for the related field.
+	Related(ctx context.Context, signature string, size int) ([]*model.MediathekBaseEntry, error)
+
+	// SearchInEntry is the resolver for the searchInEntry field.
+	SearchInEntry(ctx context.Context, signature string, query string, size int) ([]*model.MediaMatch, error)
+
+	// MediaFulltext is the resolver for the fulltext field of media.
+	MediaFulltext(ctx context.Context, obj *model.Media, offset int, length *int) (*string, error)
+
+	// MediaFulltextLength is the resolver for the fulltextLength field of media.
+	MediaFulltextLength(ctx context.Context, obj *model.Media) (int, error)
 }
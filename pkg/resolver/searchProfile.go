@@ -0,0 +1,239 @@
+package resolver
+
+import (
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types"
+	"github.com/elastic/go-elasticsearch/v8/typedapi/types/enums/operator"
+	"github.com/je4/revcat/v2/config"
+)
+
+// defaultInnerHits is the number of matching nested documents returned per nested path, if not configured
+const defaultInnerHits = 5
+
+// DefaultSearchProfile is used for clients without search profile
+var DefaultSearchProfile = &config.SearchProfile{
+	Name: "default",
+	Fields: []string{
+		"title^4",
+		"persons.name^4",
+		"collectiontitle^2",
+		"series^2",
+		"tags^2",
+		"category^1.5",
+		"abstract^1.1",
+		"notes.title^1.2",
+		"notes.note^1.0",
+		"media.*.fulltext^1.0",
+	},
+	Operator: "or",
+	Should: []config.SearchShouldConfig{{
+		Fields:          []string{"title^10", "persons.name^5"},
+		AnalyzeWildcard: true,
+	}},
+	Types: map[string][]string{
+		"author":     {"persons.name"},
+		"estate":     {"collectiontitle"},
+		"title":      {"title"},
+		"fulltext":   {"abstract^1.1", "notes.title^1.2", "notes.note^1.0", "media.*.fulltext^1.0"},
+		"collection": {"collection"},
+		"signature":  {"signature"},
+	},
+	NestedPrefixes: []string{
+		"persons.",
+		"notes.",
+		"extra.",
+		"meta.",
+		"queries.",
+		"references.",
+		"media.audio.",
+		"media.default.",
+		"media.gpx.",
+		"media.image.",
+		"media.office.",
+		"media.pdf.",
+		"media.video.",
+		"media.webrecorder.",
+	},
+}
+
+// searchProfileFields returns the fields of the search type. The search types "all" and "semantic"
+// use the fields of the profile, all other search types have to be defined in the profile.
+func searchProfileFields(profile *config.SearchProfile, searchType string) ([]string, error) {
+	if fields, ok := profile.Types[searchType]; ok {
+		return fields, nil
+	}
+	switch searchType {
+	case "", "all", "semantic":
+		return profile.Fields, nil
+	default:
+		return nil, errors.Errorf("unknown search type '%s' in search profile '%s'", searchType, profile.Name)
+	}
+}
+
+// newTextQuery creates the queries of the text search with the fields of the search type.
+// Fields of nested documents are searched with nested queries. The media fields are searched in the
+// root document, which includes the nested media documents. The matches in nested documents are
+// only returned as inner hits, if withHighlight is set.
+func newTextQuery(profile *config.SearchProfile, searchType, query string, highlightConfig config.HighlightConfig, withHighlight bool) (must []types.Query, should []types.Query, highlight *types.Highlight, err error) {
+	fields, err := searchProfileFields(profile, searchType)
+	if err != nil {
+		return nil, nil, nil, errors.WithStack(err)
+	}
+	var defaultOperator *operator.Operator
+	switch strings.ToLower(profile.Operator) {
+	case "", "or":
+		defaultOperator = &operator.Or
+	case "and":
+		defaultOperator = &operator.And
+	default:
+		return nil, nil, nil, errors.Errorf("invalid operator '%s' in search profile '%s'", profile.Operator, profile.Name)
+	}
+	innerHits := highlightConfig.InnerHits
+	if innerHits <= 0 {
+		innerHits = defaultInnerHits
+	}
+	nestedPrefixes := profile.NestedPrefixes
+	if len(nestedPrefixes) == 0 {
+		nestedPrefixes = DefaultSearchProfile.NestedPrefixes
+	}
+
+	// group the fields by their nested path
+	// fields of the root document have the empty path, nested fields like persons or notes the path of their documents
+	var paths = []string{}
+	fieldGroups := make(map[string][]string)
+	addField := func(path, field string) {
+		if _, ok := fieldGroups[path]; !ok {
+			paths = append(paths, path)
+		}
+		fieldGroups[path] = append(fieldGroups[path], field)
+	}
+	// media.* stands for all nested media paths, they are included in the root document
+	var mediaFields = []string{}
+	for _, f := range fields {
+		if mediaField, ok := strings.CutPrefix(f, "media.*."); ok {
+			addField("", f)
+			mediaFields = append(mediaFields, mediaField)
+			continue
+		}
+		found := false
+		for _, prefix := range nestedPrefixes {
+			if strings.HasPrefix(f, prefix) {
+				addField(strings.TrimSuffix(prefix, "."), f)
+				found = true
+				break
+			}
+		}
+		if !found {
+			addField("", f)
+		}
+	}
+
+	textQuery := func(fields []string) types.Query {
+		sqs := types.Query{
+			SimpleQueryString: &types.SimpleQueryStringQuery{
+				Query:           query,
+				Fields:          fields,
+				DefaultOperator: defaultOperator,
+			},
+		}
+		if profile.Fuzziness == "" {
+			return sqs
+		}
+		// the fuzzy query finds misspelled terms, the query syntax is still supported by the simple query string
+		return types.Query{Bool: &types.BoolQuery{Should: []types.Query{sqs, {
+			MultiMatch: &types.MultiMatchQuery{
+				Query:     query,
+				Fields:    fields,
+				Fuzziness: profile.Fuzziness,
+				Operator:  defaultOperator,
+			},
+		}}}}
+	}
+	nestedInnerHits := func(path string, fields []string) *types.InnerHits {
+		if !withHighlight {
+			return nil
+		}
+		return &types.InnerHits{
+			Name:      new(path),
+			Size:      new(innerHits),
+			Source_:   false,
+			Highlight: newHighlight(fields, highlightConfig.FragmentSize, highlightConfig.Fragments),
+		}
+	}
+
+	// build a query for every group and combine them with should (or)
+	var subQueries []types.Query
+	for _, path := range paths {
+		groupFields := fieldGroups[path]
+		if path != "" {
+			// wrap it in a nested query, the matches in the nested documents are highlighted as inner hits
+			subQueries = append(subQueries, types.Query{
+				Nested: &types.NestedQuery{
+					Path:      path,
+					Query:     textQuery(groupFields),
+					InnerHits: nestedInnerHits(path, groupFields),
+				},
+			})
+			continue
+		}
+		subQueries = append(subQueries, textQuery(groupFields))
+		if !withHighlight {
+			continue
+		}
+		// the media fields are highlighted per media item by the inner hits below
+		var highlightFields = []string{}
+		for _, f := range groupFields {
+			if !strings.HasPrefix(f, "media.*.") {
+				highlightFields = append(highlightFields, f)
+			}
+		}
+		if len(highlightFields) > 0 {
+			highlight = newHighlight(highlightFields, highlightConfig.FragmentSize, highlightConfig.Fragments)
+		}
+	}
+	// the matching media items are found with nested queries without boost,
+	// so that the score is the score of the media fields of the root document
+	if withHighlight && len(mediaFields) > 0 {
+		for _, prefix := range nestedPrefixes {
+			if !strings.HasPrefix(prefix, "media.") {
+				continue
+			}
+			path := strings.TrimSuffix(prefix, ".")
+			var groupFields = []string{}
+			for _, f := range mediaFields {
+				groupFields = append(groupFields, prefix+f)
+			}
+			subQueries = append(subQueries, types.Query{
+				Nested: &types.NestedQuery{
+					Path:      path,
+					Query:     textQuery(groupFields),
+					Boost:     new(float32(0)),
+					InnerHits: nestedInnerHits(path, groupFields),
+				},
+			})
+		}
+	}
+
+	// the queries of the groups as or block
+	if len(subQueries) > 0 {
+		must = append(must, types.Query{
+			Bool: &types.BoolQuery{
+				Should: subQueries,
+			},
+		})
+	}
+
+	for _, s := range profile.Should {
+		sqs := &types.SimpleQueryStringQuery{
+			Query:  query,
+			Fields: s.Fields,
+		}
+		if s.AnalyzeWildcard {
+			sqs.AnalyzeWildcard = new(true)
+		}
+		should = append(should, types.Query{SimpleQueryString: sqs})
+	}
+	return must, should, highlight, nil
+}
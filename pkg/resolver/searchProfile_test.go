@@ -0,0 +1,84 @@
+package resolver
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/je4/revcat/v2/config"
+)
+
+func TestNewTextQuery(t *testing.T) {
+	highlight := config.HighlightConfig{FragmentSize: 150, Fragments: 3}
+	if _, _, _, err := newTextQuery(DefaultSearchProfile, "everything", "ocean", highlight, false); err == nil {
+		t.Errorf("newTextQuery() with unknown search type should fail")
+	}
+
+	must, should, hl, err := newTextQuery(DefaultSearchProfile, "all", "ocean", highlight, false)
+	if err != nil {
+		t.Fatalf("newTextQuery() error = %v", err)
+	}
+	// root fields including the media fields, persons and notes
+	if len(must) != 1 || len(must[0].Bool.Should) != 3 {
+		t.Fatalf("newTextQuery() = %d groups, want 3", len(must[0].Bool.Should))
+	}
+	if must[0].Bool.Should[0].SimpleQueryString == nil || must[0].Bool.Should[1].Nested == nil || must[0].Bool.Should[1].Nested.Path != "persons" {
+		t.Errorf("newTextQuery() = %+v, want root fields first and persons nested", must[0].Bool.Should[:2])
+	}
+	if !slices.Contains(must[0].Bool.Should[0].SimpleQueryString.Fields, "media.*.fulltext^1.0") {
+		t.Errorf("newTextQuery() root fields = %v, want media fulltext", must[0].Bool.Should[0].SimpleQueryString.Fields)
+	}
+	if must[0].Bool.Should[1].Nested.InnerHits != nil || hl != nil {
+		t.Errorf("newTextQuery() without highlight has inner hits %+v or highlight %+v", must[0].Bool.Should[1].Nested.InnerHits, hl)
+	}
+	if len(should) != 1 || *should[0].SimpleQueryString.AnalyzeWildcard != true {
+		t.Errorf("newTextQuery() should = %+v", should)
+	}
+
+	must, _, hl, err = newTextQuery(DefaultSearchProfile, "all", "ocean", highlight, true)
+	if err != nil {
+		t.Fatalf("newTextQuery() error = %v", err)
+	}
+	// and the eight media paths for the inner hits
+	if len(must) != 1 || len(must[0].Bool.Should) != 11 {
+		t.Fatalf("newTextQuery() = %d groups, want 11", len(must[0].Bool.Should))
+	}
+	if inner := must[0].Bool.Should[1].Nested.InnerHits; inner == nil || *inner.Size != defaultInnerHits {
+		t.Errorf("newTextQuery() persons inner hits = %+v, want %d", inner, defaultInnerHits)
+	}
+	media := must[0].Bool.Should[3].Nested
+	if media == nil || media.Path != "media.audio" || *media.Boost != 0 || media.InnerHits == nil {
+		t.Errorf("newTextQuery() = %+v, want media inner hits without boost", media)
+	}
+	if _, ok := hl.Fields["title"]; !ok {
+		t.Errorf("newTextQuery() highlight = %+v, want title", hl.Fields)
+	}
+	if _, ok := hl.Fields["media.*.fulltext"]; ok {
+		t.Errorf("newTextQuery() highlight = %+v, want media fulltext in inner hits only", hl.Fields)
+	}
+
+	profile := &config.SearchProfile{
+		Name:      "ink",
+		Fields:    []string{"title^4"},
+		Operator:  "and",
+		Fuzziness: "AUTO",
+		Types:     map[string][]string{"author": {"persons.name"}},
+	}
+	must, should, hl, err = newTextQuery(profile, "author", "mihaylova", highlight, true)
+	if err != nil {
+		t.Fatalf("newTextQuery() error = %v", err)
+	}
+	nested := must[0].Bool.Should[0].Nested
+	if nested == nil || nested.Query.Bool == nil || nested.Query.Bool.Should[1].MultiMatch.Fuzziness != "AUTO" || nested.Query.Bool.Should[0].SimpleQueryString.DefaultOperator.Name != "and" {
+		t.Errorf("newTextQuery() = %+v, want fuzzy nested query with and operator", nested)
+	}
+	if len(should) != 0 || hl != nil {
+		t.Errorf("newTextQuery() should = %+v, highlight = %+v", should, hl)
+	}
+	if _, _, _, err := newTextQuery(profile, "title", "ocean", highlight, false); err == nil {
+		t.Errorf("newTextQuery() with search type missing in profile should fail")
+	}
+	profile.Operator = "xor"
+	if _, _, _, err := newTextQuery(profile, "all", "ocean", highlight, false); err == nil {
+		t.Errorf("newTextQuery() with invalid operator should fail")
+	}
+}
@@ -0,0 +1,172 @@
+package resolver
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+
+	"emperror.dev/errors"
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+// themaCategory is an entry of the thema taxonomy as written by cmd/csv2json
+type themaCategory struct {
+	ID       string          `json:"id"`
+	LabelDE  string          `json:"label_de"`
+	LabelEN  string          `json:"label_en"`
+	Subthema []themaCategory `json:"subthema,omitzero"`
+}
+
+// NewThema loads the thema taxonomy from the json file.
+// The codes of the taxonomy are stored with prefix in field, e.g. "category.keyword".
+func NewThema(path, field, prefix string) (*Thema, error) {
+	t := &Thema{
+		path:   path,
+		field:  field,
+		prefix: prefix,
+	}
+	if err := t.Reload(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return t, nil
+}
+
+// Thema translates the codes of the thema taxonomy, it can be reloaded while the server is running
+type Thema struct {
+	path   string
+	field  string
+	prefix string
+	lock   sync.RWMutex
+	tree   []themaCategory
+	labels map[string]*model.ThemaLabel
+}
+
+// Reload reads the taxonomy file again
+func (t *Thema) Reload() error {
+	data, err := os.ReadFile(t.path)
+	if err != nil {
+		return errors.Wrapf(err, "cannot read thema taxonomy '%s'", t.path)
+	}
+	var tree = []themaCategory{}
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return errors.Wrapf(err, "cannot unmarshal thema taxonomy '%s'", t.path)
+	}
+	var labels = map[string]*model.ThemaLabel{}
+	for _, cat := range tree {
+		parent := &model.ThemaLabel{ID: cat.ID, De: cat.LabelDE, En: cat.LabelEN}
+		labels[cat.ID] = parent
+		for _, sub := range cat.Subthema {
+			labels[sub.ID] = &model.ThemaLabel{ID: sub.ID, De: sub.LabelDE, En: sub.LabelEN, Parent: parent}
+		}
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.tree = tree
+	t.labels = labels
+	return nil
+}
+
+// label returns the label of a field value or nil, if the value is no code of the taxonomy
+func (t *Thema) label(value string) *model.ThemaLabel {
+	if t == nil {
+		return nil
+	}
+	code, ok := strings.CutPrefix(value, t.prefix)
+	if !ok {
+		return nil
+	}
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.labels[code]
+}
+
+// labelEntry adds the labels of the categories to the entry
+func (t *Thema) labelEntry(entry *model.MediathekBaseEntry) {
+	if t == nil || entry == nil {
+		return
+	}
+	for _, category := range entry.Category {
+		if label := t.label(category); label != nil {
+			entry.CategoryThema = append(entry.CategoryThema, label)
+		}
+	}
+}
+
+// labelFacets adds the labels to the facet values
+func (t *Thema) labelFacets(facets []*model.Facet) {
+	if t == nil {
+		return
+	}
+	var labelHierarchy func(values []*model.FacetValueHierarchy)
+	labelHierarchy = func(values []*model.FacetValueHierarchy) {
+		for _, value := range values {
+			value.Thema = t.label(value.Path)
+			labelHierarchy(value.Children)
+		}
+	}
+	for _, facet := range facets {
+		for _, value := range facet.Values {
+			switch v := value.(type) {
+			case *model.FacetValueString:
+				v.Thema = t.label(v.StrVal)
+			case *model.FacetValueHierarchy:
+				labelHierarchy([]*model.FacetValueHierarchy{v})
+			}
+		}
+	}
+}
+
+// labelResult adds the labels to the entries and facets of a search result
+func (t *Thema) labelResult(result *model.SearchResult) {
+	if t == nil || result == nil {
+		return
+	}
+	for _, edge := range result.Edges {
+		t.labelEntry(edge.Base)
+	}
+	t.labelFacets(result.Facets)
+}
+
+// nodeValues returns the field values of every node of the taxonomy.
+// A theme contains the values of its subthemes.
+func (t *Thema) nodeValues() map[string][]string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	var values = map[string][]string{}
+	for _, cat := range t.tree {
+		values[cat.ID] = []string{t.prefix + cat.ID}
+		for _, sub := range cat.Subthema {
+			values[sub.ID] = []string{t.prefix + sub.ID}
+			values[cat.ID] = append(values[cat.ID], t.prefix+sub.ID)
+		}
+	}
+	return values
+}
+
+// nodes creates the theme tree with the document counts per node
+func (t *Thema) nodes(counts map[string]int) []*model.ThemaNode {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	var result = []*model.ThemaNode{}
+	for _, cat := range t.tree {
+		node := &model.ThemaNode{
+			ID:       cat.ID,
+			De:       cat.LabelDE,
+			En:       cat.LabelEN,
+			Count:    counts[cat.ID],
+			Children: []*model.ThemaNode{},
+		}
+		for _, sub := range cat.Subthema {
+			node.Children = append(node.Children, &model.ThemaNode{
+				ID:       sub.ID,
+				De:       sub.LabelDE,
+				En:       sub.LabelEN,
+				Count:    counts[sub.ID],
+				Children: []*model.ThemaNode{},
+			})
+		}
+		result = append(result, node)
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package resolver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/je4/revcat/v2/tools/graph/model"
+)
+
+const testThemaJSON = `[
+  {"id": "Werke", "label_de": "Werke", "label_en": "Works", "subthema": [
+    {"id": "Performance Art", "label_de": "Performancekunst", "label_en": "Performance art"}
+  ]}
+]`
+
+func newTestThema(t *testing.T) (*Thema, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "thema_label.json")
+	if err := os.WriteFile(path, []byte(testThemaJSON), 0644); err != nil {
+		t.Fatalf("cannot write taxonomy: %v", err)
+	}
+	thema, err := NewThema(path, "category.keyword", "zotero2!!")
+	if err != nil {
+		t.Fatalf("NewThema() error = %v", err)
+	}
+	return thema, path
+}
+
+func TestThemaLabel(t *testing.T) {
+	thema, path := newTestThema(t)
+	label := thema.label("zotero2!!Performance Art")
+	if label == nil || label.En != "Performance art" || label.Parent == nil || label.Parent.ID != "Werke" {
+		t.Fatalf("label() = %+v, want subtheme of Werke", label)
+	}
+	if label := thema.label("Performance Art"); label != nil {
+		t.Errorf("label() without prefix = %+v, want nil", label)
+	}
+
+	facets := []*model.Facet{{Name: "category", Values: []model.FacetValue{
+		&model.FacetValueString{StrVal: "zotero2!!Werke", Count: 2},
+		&model.FacetValueHierarchy{Path: "zotero2", Children: []*model.FacetValueHierarchy{{Path: "zotero2!!Werke"}}},
+	}}}
+	thema.labelFacets(facets)
+	if v := facets[0].Values[0].(*model.FacetValueString); v.Thema == nil || v.Thema.De != "Werke" {
+		t.Errorf("labelFacets() = %+v", v)
+	}
+	if v := facets[0].Values[1].(*model.FacetValueHierarchy); v.Thema != nil || v.Children[0].Thema == nil {
+		t.Errorf("labelFacets() hierarchy = %+v", v)
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"id": "Werke", "label_de": "Arbeiten", "label_en": "Works"}]`), 0644); err != nil {
+		t.Fatalf("cannot write taxonomy: %v", err)
+	}
+	if err := thema.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if label := thema.label("zotero2!!Werke"); label == nil || label.De != "Arbeiten" {
+		t.Errorf("label() after reload = %+v", label)
+	}
+	if label := thema.label("zotero2!!Performance Art"); label != nil {
+		t.Errorf("label() after reload = %+v, want nil", label)
+	}
+}
+
+func TestBadgerResolver_ThemaTree(t *testing.T) {
+	r, _ := newBadgerTestResolver(t)
+	if _, err := r.ThemaTree(guestContext(), nil); err == nil {
+		t.Errorf("ThemaTree() without taxonomy should fail")
+	}
+	r.thema, _ = newTestThema(t)
+	nodes, err := r.ThemaTree(guestContext(), nil)
+	if err != nil {
+		t.Fatalf("ThemaTree() error = %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].Count != 1 || len(nodes[0].Children) != 1 || nodes[0].Children[0].Count != 1 {
+		t.Fatalf("ThemaTree() = %+v", nodes)
+	}
+
+	result, err := r.Search(guestContext(), "all", "", nil, nil, nil, nil, nil, nil, nil, nil, nil, false, false, nil)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	for _, edge := range result.Edges {
+		if edge.ID == "zotero2-1.A" && (len(edge.Base.CategoryThema) != 1 || edge.Base.CategoryThema[0].ID != "Performance Art") {
+			t.Errorf("Search() category labels = %+v", edge.Base.CategoryThema)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package sourcetype
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type DatePrecision string
+
+const (
+	DatePrecisionDay     DatePrecision = "day"
+	DatePrecisionMonth   DatePrecision = "month"
+	DatePrecisionYear    DatePrecision = "year"
+	DatePrecisionDecade  DatePrecision = "decade"
+	DatePrecisionCentury DatePrecision = "century"
+)
+
+// datePrecisionOrder orders the precisions from fine to coarse
+var datePrecisionOrder = map[DatePrecision]int{
+	DatePrecisionDay:     0,
+	DatePrecisionMonth:   1,
+	DatePrecisionYear:    2,
+	DatePrecisionDecade:  3,
+	DatePrecisionCentury: 4,
+}
+
+// DateInterval is the normalized form of a free text date.
+// Start is the first and End the last day of the interval, both including.
+type DateInterval struct {
+	Start       time.Time
+	End         time.Time
+	Precision   DatePrecision
+	Approximate bool
+}
+
+var monthNames = map[string]time.Month{
+	"jan": time.January, "januar": time.January, "january": time.January, "jänner": time.January,
+	"feb": time.February, "februar": time.February, "february": time.February,
+	"mar": time.March, "mär": time.March, "märz": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"mai": time.May, "may": time.May,
+	"jun": time.June, "juni": time.June, "june": time.June,
+	"jul": time.July, "juli": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"okt": time.October, "oct": time.October, "oktober": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dez": time.December, "dec": time.December, "dezember": time.December, "december": time.December,
+}
+
+var (
+	dateApproximateRegexp = regexp.MustCompile(`(?i)^(?:ca\.|c\.|approx\.|(?:ca|circa|um|approx)\s)\s*`)
+	dateISORegexp         = regexp.MustCompile(`^(\d{4})(?:-(\d{1,2})(?:-(\d{1,2}))?)?$`)
+	dateGermanRegexp      = regexp.MustCompile(`^(?:(\d{1,2})\.\s*)?(\d{1,2})\.\s*(\d{4})$`)
+	dateMonthNameRegexp   = regexp.MustCompile(`(?i)^(?:(\d{1,2})\.?\s+)?(\pL+)\.?\s+(\d{4})$`)
+	dateDecadeRegexp      = regexp.MustCompile(`(?i)^(\d{3})0(?:s|er|er jahre|'s)$`)
+	dateUnspecifiedRegexp = regexp.MustCompile(`(?i)^(\d{2})(\d|[xu])[xu]$`)
+	dateCenturyRegexp     = regexp.MustCompile(`(?i)^(\d{1,2})\.?\s*(?:jh\.?|jhd\.?|jahrhundert|th century|st century|nd century|rd century)$`)
+	dateYearRangeRegexp   = regexp.MustCompile(`^(\d{4})\s*-\s*(\d{4})$`)
+	dateRangeSeparators   = []string{"/", "–", " - ", " bis ", " to "}
+)
+
+// ParseDate normalizes a free text date like "1998", "ca. 1970-1975", "2003-05-12", "12.05.2003", "1970er", "19XX" or
+// the EDTF interval "1970/1975~" to the interval of days it covers
+func ParseDate(value string) (*DateInterval, error) {
+	str := strings.TrimSpace(value)
+	str = strings.Trim(str, "[]()")
+	if str == "" {
+		return nil, errors.New("empty date")
+	}
+	var approximate bool
+	if loc := dateApproximateRegexp.FindStringIndex(str); loc != nil {
+		approximate = true
+		str = str[loc[1]:]
+	}
+	if trimmed := strings.TrimRight(str, "~?%"); trimmed != str {
+		approximate = true
+		str = trimmed
+	}
+
+	if date, err := parseSingleDate(str); err == nil {
+		date.Approximate = date.Approximate || approximate
+		return date, nil
+	}
+	var parts []string
+	if matches := dateYearRangeRegexp.FindStringSubmatch(str); matches != nil {
+		parts = matches[1:]
+	} else {
+		for _, sep := range dateRangeSeparators {
+			if before, after, ok := strings.Cut(str, sep); ok {
+				parts = []string{before, after}
+				break
+			}
+		}
+	}
+	if parts == nil {
+		return nil, errors.Errorf("cannot parse date '%s'", value)
+	}
+	from, err := parseSingleDate(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse start of date '%s'", value)
+	}
+	to, err := parseSingleDate(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot parse end of date '%s'", value)
+	}
+	if to.End.Before(from.Start) {
+		return nil, errors.Errorf("end before start in date '%s'", value)
+	}
+	date := &DateInterval{
+		Start:       from.Start,
+		End:         to.End,
+		Precision:   from.Precision,
+		Approximate: approximate || from.Approximate || to.Approximate,
+	}
+	if datePrecisionOrder[to.Precision] > datePrecisionOrder[from.Precision] {
+		date.Precision = to.Precision
+	}
+	return date, nil
+}
+
+// parseSingleDate parses a date without interval
+func parseSingleDate(str string) (*DateInterval, error) {
+	var approximate bool
+	if trimmed := strings.TrimRight(str, "~?%"); trimmed != str {
+		approximate = true
+		str = trimmed
+	}
+	var date *DateInterval
+	var err error
+	if matches := dateISORegexp.FindStringSubmatch(str); matches != nil {
+		date, err = newDateInterval(matches[1], matches[2], matches[3])
+	} else if matches := dateGermanRegexp.FindStringSubmatch(str); matches != nil {
+		date, err = newDateInterval(matches[3], matches[2], matches[1])
+	} else if matches := dateMonthNameRegexp.FindStringSubmatch(str); matches != nil {
+		month, ok := monthNames[strings.ToLower(matches[2])]
+		if !ok {
+			return nil, errors.Errorf("unknown month '%s'", matches[2])
+		}
+		date, err = newDateInterval(matches[3], strconv.Itoa(int(month)), matches[1])
+	} else if matches := dateDecadeRegexp.FindStringSubmatch(str); matches != nil {
+		start, _ := strconv.Atoi(matches[1] + "0")
+		date = newYearInterval(start, start+9, DatePrecisionDecade)
+	} else if matches := dateUnspecifiedRegexp.FindStringSubmatch(str); matches != nil {
+		// EDTF unspecified digits, 197X is a decade and 19XX a century
+		if digit, convErr := strconv.Atoi(matches[2]); convErr == nil {
+			start, _ := strconv.Atoi(matches[1] + strconv.Itoa(digit) + "0")
+			date = newYearInterval(start, start+9, DatePrecisionDecade)
+		} else {
+			start, _ := strconv.Atoi(matches[1] + "00")
+			date = newYearInterval(start, start+99, DatePrecisionCentury)
+		}
+	} else if matches := dateCenturyRegexp.FindStringSubmatch(str); matches != nil {
+		century, _ := strconv.Atoi(matches[1])
+		if century < 1 {
+			return nil, errors.Errorf("invalid century '%s'", str)
+		}
+		start := (century - 1) * 100
+		date = newYearInterval(start, start+99, DatePrecisionCentury)
+	} else {
+		return nil, errors.Errorf("cannot parse date '%s'", str)
+	}
+	if err != nil {
+		return nil, err
+	}
+	date.Approximate = approximate
+	return date, nil
+}
+
+func newYearInterval(from, to int, precision DatePrecision) *DateInterval {
+	return &DateInterval{
+		Start:     time.Date(from, time.January, 1, 0, 0, 0, 0, time.UTC),
+		End:       time.Date(to, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Precision: precision,
+	}
+}
+
+// newDateInterval creates the interval of a year, month or day. Empty month or day strings reduce the precision
+func newDateInterval(yearStr, monthStr, dayStr string) (*DateInterval, error) {
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid year '%s'", yearStr)
+	}
+	if monthStr == "" {
+		return newYearInterval(year, year, DatePrecisionYear), nil
+	}
+	month, err := strconv.Atoi(monthStr)
+	if err != nil || month < 1 || month > 12 {
+		return nil, errors.Errorf("invalid month '%s'", monthStr)
+	}
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	if dayStr == "" {
+		return &DateInterval{
+			Start:     start,
+			End:       start.AddDate(0, 1, -1),
+			Precision: DatePrecisionMonth,
+		}, nil
+	}
+	day, err := strconv.Atoi(dayStr)
+	if err != nil || day < 1 || day > start.AddDate(0, 1, -1).Day() {
+		return nil, errors.Errorf("invalid day '%s'", dayStr)
+	}
+	start = start.AddDate(0, 0, day-1)
+	return &DateInterval{
+		Start:     start,
+		End:       start,
+		Precision: DatePrecisionDay,
+	}, nil
+}
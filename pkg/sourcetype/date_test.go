@@ -0,0 +1,82 @@
+package sourcetype
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value       string
+		start       string
+		end         string
+		precision   DatePrecision
+		approximate bool
+	}{
+		{"1998", "1998-01-01", "1998-12-31", DatePrecisionYear, false},
+		{"2003-05-12", "2003-05-12", "2003-05-12", DatePrecisionDay, false},
+		{"2004-02", "2004-02-01", "2004-02-29", DatePrecisionMonth, false},
+		{"12.05.2003", "2003-05-12", "2003-05-12", DatePrecisionDay, false},
+		{"05.2003", "2003-05-01", "2003-05-31", DatePrecisionMonth, false},
+		{"12. Mai 2003", "2003-05-12", "2003-05-12", DatePrecisionDay, false},
+		{"March 2003", "2003-03-01", "2003-03-31", DatePrecisionMonth, false},
+		{"ca. 1970-1975", "1970-01-01", "1975-12-31", DatePrecisionYear, true},
+		{"um 1970", "1970-01-01", "1970-12-31", DatePrecisionYear, true},
+		{"[1985]", "1985-01-01", "1985-12-31", DatePrecisionYear, false},
+		{"1970/1975-06~", "1970-01-01", "1975-06-30", DatePrecisionYear, true},
+		{"1970?", "1970-01-01", "1970-12-31", DatePrecisionYear, true},
+		{"2001-03-04/2001-03-06", "2001-03-04", "2001-03-06", DatePrecisionDay, false},
+		{"1970er", "1970-01-01", "1979-12-31", DatePrecisionDecade, false},
+		{"1980s", "1980-01-01", "1989-12-31", DatePrecisionDecade, false},
+		{"197X", "1970-01-01", "1979-12-31", DatePrecisionDecade, false},
+		{"19XX", "1900-01-01", "1999-12-31", DatePrecisionCentury, false},
+		{"19. Jh.", "1800-01-01", "1899-12-31", DatePrecisionCentury, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			date, err := ParseDate(tt.value)
+			if err != nil {
+				t.Fatalf("ParseDate() error = %v", err)
+			}
+			if start := date.Start.Format(time.DateOnly); start != tt.start {
+				t.Errorf("ParseDate() start = %s, want %s", start, tt.start)
+			}
+			if end := date.End.Format(time.DateOnly); end != tt.end {
+				t.Errorf("ParseDate() end = %s, want %s", end, tt.end)
+			}
+			if date.Precision != tt.precision {
+				t.Errorf("ParseDate() precision = %s, want %s", date.Precision, tt.precision)
+			}
+			if date.Approximate != tt.approximate {
+				t.Errorf("ParseDate() approximate = %v, want %v", date.Approximate, tt.approximate)
+			}
+		})
+	}
+}
+
+func TestParseDateInvalid(t *testing.T) {
+	for _, value := range []string{"", "o.J.", "unbekannt", "2003-13", "31.02.2003", "1980-1970", "Umbruch 1970", "Foo 2003"} {
+		if date, err := ParseDate(value); err == nil {
+			t.Errorf("ParseDate(%q) = %+v, want error", value, date)
+		}
+	}
+}
+
+func TestSourceData_NormalizeDate(t *testing.T) {
+	s := &SourceData{}
+	if err := s.SetDate("ca. 1970-1975"); err != nil {
+		t.Fatalf("SetDate() error = %v", err)
+	}
+	if s.DateStart == nil || s.DateEnd == nil || s.DatePrecision != string(DatePrecisionYear) {
+		t.Fatalf("SetDate() did not normalize the date: %v %v %s", s.DateStart, s.DateEnd, s.DatePrecision)
+	}
+	if err := s.SetDate("o.J."); err != nil {
+		t.Fatalf("SetDate() error = %v", err)
+	}
+	if s.DateStart != nil || s.DateEnd != nil || s.DatePrecision != "" {
+		t.Errorf("SetDate() kept the interval of the previous date")
+	}
+	if err := s.NormalizeDate(); err == nil {
+		t.Errorf("NormalizeDate() with invalid date should fail")
+	}
+}
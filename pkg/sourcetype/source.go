@@ -21,6 +21,9 @@ type SourceData struct {
 	Series            string                           `json:"series"`
 	Place             string                           `json:"place"`
 	Date              string                           `json:"date"`
+	DateStart         *time.Time                       `json:"datestart,omitempty"`
+	DateEnd           *time.Time                       `json:"dateend,omitempty"`
+	DatePrecision     string                           `json:"dateprecision,omitempty"`
 	CollectionTitle   string                           `json:"collectiontitle"`
 	Persons           []Person                         `json:"persons"`
 	ACL               map[string][]string              `json:"acl"`
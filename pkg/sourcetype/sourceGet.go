@@ -42,6 +42,18 @@ func (s *SourceData) GetDate() string {
 	return s.Date
 }
 
+// GetDateInterval returns the normalized interval of the date. Records without stored interval are parsed
+func (s *SourceData) GetDateInterval() (*DateInterval, error) {
+	if s.DateStart != nil && s.DateEnd != nil {
+		return &DateInterval{
+			Start:     *s.DateStart,
+			End:       *s.DateEnd,
+			Precision: DatePrecision(s.DatePrecision),
+		}, nil
+	}
+	return ParseDate(s.Date)
+}
+
 func (s *SourceData) GetCollectionTitle() string {
 	return s.CollectionTitle
 }
@@ -42,8 +42,26 @@ func (s *SourceData) SetPlace(place string) error {
 	return nil
 }
 
+// SetDate sets the free text date and its normalized interval, if the date can be parsed
 func (s *SourceData) SetDate(date string) error {
 	s.Date = date
+	// dates, which cannot be parsed, are kept as free text only
+	_ = s.NormalizeDate()
+	return nil
+}
+
+// NormalizeDate sets the normalized interval of the free text date.
+// If the date cannot be parsed, the interval is removed and the parse error is returned
+func (s *SourceData) NormalizeDate() error {
+	s.DateStart, s.DateEnd, s.DatePrecision = nil, nil, ""
+	if s.Date == "" {
+		return nil
+	}
+	date, err := ParseDate(s.Date)
+	if err != nil {
+		return err
+	}
+	s.DateStart, s.DateEnd, s.DatePrecision = &date.Start, &date.End, string(date.Precision)
 	return nil
 }
 
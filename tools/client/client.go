@@ -9,8 +9,13 @@ import (
 )
 
 type RevCatGraphQLClient interface {
+	Autocomplete(ctx context.Context, prefix string, fields []string, size int64, interceptors ...clientv2.RequestInterceptor) (*Autocomplete, error)
 	MediathekEntries(ctx context.Context, signatures []string, interceptors ...clientv2.RequestInterceptor) (*MediathekEntries, error)
-	Search(ctx context.Context, searchtype string, query string, facets []*InFacet, filter []*InFilter, vector []float64, first *int64, size *int64, cursor *string, sort []*SortField, interceptors ...clientv2.RequestInterceptor) (*Search, error)
+	Related(ctx context.Context, signature string, size int64, interceptors ...clientv2.RequestInterceptor) (*Related, error)
+	Search(ctx context.Context, searchtype string, query string, advancedQuery *InAdvancedQuery, facets []*InFacet, filter []*InFilter, vector []float64, vectorOptions *InVectorOptions, first *int64, size *int64, cursor *string, sort []*SortField, autoCorrect bool, explain bool, collapse *InCollapse, interceptors ...clientv2.RequestInterceptor) (*Search, error)
+	SearchInEntry(ctx context.Context, signature string, query string, size int64, interceptors ...clientv2.RequestInterceptor) (*SearchInEntry, error)
+	SearchRequest(ctx context.Context, searchtype string, query string, advancedQuery *InAdvancedQuery, facets []*InFacet, filter []*InFilter, vector []float64, vectorOptions *InVectorOptions, first *int64, size *int64, cursor *string, sort []*SortField, collapse *InCollapse, interceptors ...clientv2.RequestInterceptor) (*SearchRequest, error)
+	ThemaTree(ctx context.Context, filter []*InFilter, interceptors ...clientv2.RequestInterceptor) (*ThemaTree, error)
 }
 
 type Client struct {
@@ -30,7 +35,11 @@ type MediathekBaseFragment struct {
 	Series          *string                      "json:\"series,omitempty\" graphql:\"series\""
 	Place           *string                      "json:\"place,omitempty\" graphql:\"place\""
 	Date            *string                      "json:\"date,omitempty\" graphql:\"date\""
+	DateStart       *string                      "json:\"dateStart,omitempty\" graphql:\"dateStart\""
+	DateEnd         *string                      "json:\"dateEnd,omitempty\" graphql:\"dateEnd\""
+	DatePrecision   *string                      "json:\"datePrecision,omitempty\" graphql:\"datePrecision\""
 	Category        []string                     "json:\"category,omitempty\" graphql:\"category\""
+	CategoryThema   []*ThemaLabelFragment        "json:\"categoryThema,omitempty\" graphql:\"categoryThema\""
 	Tags            []string                     "json:\"tags,omitempty\" graphql:\"tags\""
 	URL             *string                      "json:\"url,omitempty\" graphql:\"url\""
 	Publisher       *string                      "json:\"publisher,omitempty\" graphql:\"publisher\""
@@ -93,12 +102,36 @@ func (t *MediathekBaseFragment) GetDate() *string {
 	}
 	return t.Date
 }
+func (t *MediathekBaseFragment) GetDateStart() *string {
+	if t == nil {
+		t = &MediathekBaseFragment{}
+	}
+	return t.DateStart
+}
+func (t *MediathekBaseFragment) GetDateEnd() *string {
+	if t == nil {
+		t = &MediathekBaseFragment{}
+	}
+	return t.DateEnd
+}
+func (t *MediathekBaseFragment) GetDatePrecision() *string {
+	if t == nil {
+		t = &MediathekBaseFragment{}
+	}
+	return t.DatePrecision
+}
 func (t *MediathekBaseFragment) GetCategory() []string {
 	if t == nil {
 		t = &MediathekBaseFragment{}
 	}
 	return t.Category
 }
+func (t *MediathekBaseFragment) GetCategoryThema() []*ThemaLabelFragment {
+	if t == nil {
+		t = &MediathekBaseFragment{}
+	}
+	return t.CategoryThema
+}
 func (t *MediathekBaseFragment) GetTags() []string {
 	if t == nil {
 		t = &MediathekBaseFragment{}
@@ -172,6 +205,38 @@ func (t *MediathekBaseFragment) GetACL() []*MediathekBaseFragment_ACL {
 	return t.ACL
 }
 
+type ThemaLabelFragment struct {
+	ID     string                     "json:\"id\" graphql:\"id\""
+	De     string                     "json:\"de\" graphql:\"de\""
+	En     string                     "json:\"en\" graphql:\"en\""
+	Parent *ThemaLabelFragment_Parent "json:\"parent,omitempty\" graphql:\"parent\""
+}
+
+func (t *ThemaLabelFragment) GetID() string {
+	if t == nil {
+		t = &ThemaLabelFragment{}
+	}
+	return t.ID
+}
+func (t *ThemaLabelFragment) GetDe() string {
+	if t == nil {
+		t = &ThemaLabelFragment{}
+	}
+	return t.De
+}
+func (t *ThemaLabelFragment) GetEn() string {
+	if t == nil {
+		t = &ThemaLabelFragment{}
+	}
+	return t.En
+}
+func (t *ThemaLabelFragment) GetParent() *ThemaLabelFragment_Parent {
+	if t == nil {
+		t = &ThemaLabelFragment{}
+	}
+	return t.Parent
+}
+
 type MultiLangFragment struct {
 	Lang       string "json:\"lang\" graphql:\"lang\""
 	Value      string "json:\"value\" graphql:\"value\""
@@ -259,14 +324,17 @@ func (t *MediaCountFragment) GetCount() int64 {
 }
 
 type MediaItemFragment struct {
-	Name        string  "json:\"name\" graphql:\"name\""
-	Mimetype    string  "json:\"mimetype\" graphql:\"mimetype\""
-	Pronom      *string "json:\"pronom,omitempty\" graphql:\"pronom\""
-	Type        string  "json:\"type\" graphql:\"type\""
-	URI         string  "json:\"uri\" graphql:\"uri\""
-	Orientation int64   "json:\"orientation\" graphql:\"orientation\""
-	Width       int64   "json:\"width\" graphql:\"width\""
-	Height      int64   "json:\"height\" graphql:\"height\""
+	Name        string   "json:\"name\" graphql:\"name\""
+	Mimetype    string   "json:\"mimetype\" graphql:\"mimetype\""
+	Pronom      *string  "json:\"pronom,omitempty\" graphql:\"pronom\""
+	Type        string   "json:\"type\" graphql:\"type\""
+	URI         string   "json:\"uri\" graphql:\"uri\""
+	Orientation int64    "json:\"orientation\" graphql:\"orientation\""
+	Width       int64    "json:\"width\" graphql:\"width\""
+	Height      int64    "json:\"height\" graphql:\"height\""
+	Matched     bool     "json:\"matched\" graphql:\"matched\""
+	Score       *float64 "json:\"score,omitempty\" graphql:\"score\""
+	Snippets    []string "json:\"snippets,omitempty\" graphql:\"snippets\""
 }
 
 func (t *MediaItemFragment) GetName() string {
@@ -317,6 +385,24 @@ func (t *MediaItemFragment) GetHeight() int64 {
 	}
 	return t.Height
 }
+func (t *MediaItemFragment) GetMatched() bool {
+	if t == nil {
+		t = &MediaItemFragment{}
+	}
+	return t.Matched
+}
+func (t *MediaItemFragment) GetScore() *float64 {
+	if t == nil {
+		t = &MediaItemFragment{}
+	}
+	return t.Score
+}
+func (t *MediaItemFragment) GetSnippets() []string {
+	if t == nil {
+		t = &MediaItemFragment{}
+	}
+	return t.Snippets
+}
 
 type MediaListFragment struct {
 	Type  string               "json:\"type\" graphql:\"type\""
@@ -394,8 +480,9 @@ func (t *PageInfoFragment) GetEndCursor() string {
 }
 
 type FacetValueStringFragment struct {
-	StrVal string "json:\"strVal\" graphql:\"strVal\""
-	Count  int64  "json:\"count\" graphql:\"count\""
+	StrVal string              "json:\"strVal\" graphql:\"strVal\""
+	Count  int64               "json:\"count\" graphql:\"count\""
+	Thema  *ThemaLabelFragment "json:\"thema,omitempty\" graphql:\"thema\""
 }
 
 func (t *FacetValueStringFragment) GetStrVal() string {
@@ -410,6 +497,12 @@ func (t *FacetValueStringFragment) GetCount() int64 {
 	}
 	return t.Count
 }
+func (t *FacetValueStringFragment) GetThema() *ThemaLabelFragment {
+	if t == nil {
+		t = &FacetValueStringFragment{}
+	}
+	return t.Thema
+}
 
 type FacetValueIntFragment struct {
 	IntVal int64 "json:\"intVal\" graphql:\"intVal\""
@@ -429,9 +522,75 @@ func (t *FacetValueIntFragment) GetCount() int64 {
 	return t.Count
 }
 
+type FacetValueRangeFragment struct {
+	Key   string  "json:\"key\" graphql:\"key\""
+	From  *string "json:\"from,omitempty\" graphql:\"from\""
+	To    *string "json:\"to,omitempty\" graphql:\"to\""
+	Count int64   "json:\"count\" graphql:\"count\""
+}
+
+func (t *FacetValueRangeFragment) GetKey() string {
+	if t == nil {
+		t = &FacetValueRangeFragment{}
+	}
+	return t.Key
+}
+func (t *FacetValueRangeFragment) GetFrom() *string {
+	if t == nil {
+		t = &FacetValueRangeFragment{}
+	}
+	return t.From
+}
+func (t *FacetValueRangeFragment) GetTo() *string {
+	if t == nil {
+		t = &FacetValueRangeFragment{}
+	}
+	return t.To
+}
+func (t *FacetValueRangeFragment) GetCount() int64 {
+	if t == nil {
+		t = &FacetValueRangeFragment{}
+	}
+	return t.Count
+}
+
+type FacetValueHierarchyFragment struct {
+	Path  string              "json:\"path\" graphql:\"path\""
+	Label string              "json:\"label\" graphql:\"label\""
+	Count int64               "json:\"count\" graphql:\"count\""
+	Thema *ThemaLabelFragment "json:\"thema,omitempty\" graphql:\"thema\""
+}
+
+func (t *FacetValueHierarchyFragment) GetPath() string {
+	if t == nil {
+		t = &FacetValueHierarchyFragment{}
+	}
+	return t.Path
+}
+func (t *FacetValueHierarchyFragment) GetLabel() string {
+	if t == nil {
+		t = &FacetValueHierarchyFragment{}
+	}
+	return t.Label
+}
+func (t *FacetValueHierarchyFragment) GetCount() int64 {
+	if t == nil {
+		t = &FacetValueHierarchyFragment{}
+	}
+	return t.Count
+}
+func (t *FacetValueHierarchyFragment) GetThema() *ThemaLabelFragment {
+	if t == nil {
+		t = &FacetValueHierarchyFragment{}
+	}
+	return t.Thema
+}
+
 type FacetValueFragment struct {
-	FacetValueString FacetValueStringFragment "graphql:\"... on FacetValueString\""
-	FacetValueInt    FacetValueIntFragment    "graphql:\"... on FacetValueInt\""
+	FacetValueString    FacetValueStringFragment               "graphql:\"... on FacetValueString\""
+	FacetValueInt       FacetValueIntFragment                  "graphql:\"... on FacetValueInt\""
+	FacetValueRange     FacetValueRangeFragment                "graphql:\"... on FacetValueRange\""
+	FacetValueHierarchy FacetValueFragment_FacetValueHierarchy "graphql:\"... on FacetValueHierarchy\""
 }
 
 func (t *FacetValueFragment) GetFacetValueString() *FacetValueStringFragment {
@@ -446,6 +605,18 @@ func (t *FacetValueFragment) GetFacetValueInt() *FacetValueIntFragment {
 	}
 	return &t.FacetValueInt
 }
+func (t *FacetValueFragment) GetFacetValueRange() *FacetValueRangeFragment {
+	if t == nil {
+		t = &FacetValueFragment{}
+	}
+	return &t.FacetValueRange
+}
+func (t *FacetValueFragment) GetFacetValueHierarchy() *FacetValueFragment_FacetValueHierarchy {
+	if t == nil {
+		t = &FacetValueFragment{}
+	}
+	return &t.FacetValueHierarchy
+}
 
 type FacetFragment struct {
 	Name   string                "json:\"name\" graphql:\"name\""
@@ -543,253 +714,1442 @@ func (t *PersonFragment) GetIdentifier() []*PersonIdentifierFragment {
 	return t.Identifier
 }
 
-type MediathekBaseFragment_ACL struct {
-	Groups []string "json:\"groups\" graphql:\"groups\""
-	Name   string   "json:\"name\" graphql:\"name\""
+type ThemaNodeFragment struct {
+	ID    string "json:\"id\" graphql:\"id\""
+	De    string "json:\"de\" graphql:\"de\""
+	En    string "json:\"en\" graphql:\"en\""
+	Count int64  "json:\"count\" graphql:\"count\""
 }
 
-func (t *MediathekBaseFragment_ACL) GetGroups() []string {
+func (t *ThemaNodeFragment) GetID() string {
 	if t == nil {
-		t = &MediathekBaseFragment_ACL{}
+		t = &ThemaNodeFragment{}
 	}
-	return t.Groups
+	return t.ID
 }
-func (t *MediathekBaseFragment_ACL) GetName() string {
+func (t *ThemaNodeFragment) GetDe() string {
 	if t == nil {
-		t = &MediathekBaseFragment_ACL{}
+		t = &ThemaNodeFragment{}
 	}
-	return t.Name
+	return t.De
+}
+func (t *ThemaNodeFragment) GetEn() string {
+	if t == nil {
+		t = &ThemaNodeFragment{}
+	}
+	return t.En
+}
+func (t *ThemaNodeFragment) GetCount() int64 {
+	if t == nil {
+		t = &ThemaNodeFragment{}
+	}
+	return t.Count
 }
 
-type MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL struct {
-	Groups []string "json:\"groups\" graphql:\"groups\""
-	Name   string   "json:\"name\" graphql:\"name\""
+type MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
 }
 
-func (t *MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL) GetGroups() []string {
+func (t *MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetDe() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL{}
+		t = &MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
 	}
-	return t.Groups
+	return t.De
 }
-func (t *MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL) GetName() string {
+func (t *MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetEn() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL{}
+		t = &MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
 	}
-	return t.Name
+	return t.En
+}
+func (t *MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
 }
 
-type MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL struct {
+type MediathekBaseFragment_ACL struct {
 	Groups []string "json:\"groups\" graphql:\"groups\""
 	Name   string   "json:\"name\" graphql:\"name\""
 }
 
-func (t *MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL) GetGroups() []string {
+func (t *MediathekBaseFragment_ACL) GetGroups() []string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL{}
+		t = &MediathekBaseFragment_ACL{}
 	}
 	return t.Groups
 }
-func (t *MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL) GetName() string {
+func (t *MediathekBaseFragment_ACL) GetName() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL{}
+		t = &MediathekBaseFragment_ACL{}
 	}
 	return t.Name
 }
 
-type MediathekEntries_MediathekEntries struct {
-	Typename       *string                  "json:\"__typename,omitempty\" graphql:\"__typename\""
-	Abstract       []*MultiLangFragment     "json:\"abstract,omitempty\" graphql:\"abstract\""
-	Base           *MediathekBaseFragment   "json:\"base\" graphql:\"base\""
-	Extra          []*KeyValueFragment      "json:\"extra,omitempty\" graphql:\"extra\""
-	ID             string                   "json:\"id\" graphql:\"id\""
-	Media          []*MediaListFragment     "json:\"media,omitempty\" graphql:\"media\""
-	Notes          []*NoteFragment          "json:\"notes,omitempty\" graphql:\"notes\""
-	ReferencesFull []*MediathekBaseFragment "json:\"referencesFull,omitempty\" graphql:\"referencesFull\""
+type ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
 }
 
-func (t *MediathekEntries_MediathekEntries) GetTypename() *string {
+func (t *ThemaLabelFragment_Parent) GetDe() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries{}
+		t = &ThemaLabelFragment_Parent{}
 	}
-	return t.Typename
+	return t.De
 }
-func (t *MediathekEntries_MediathekEntries) GetAbstract() []*MultiLangFragment {
+func (t *ThemaLabelFragment_Parent) GetEn() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries{}
+		t = &ThemaLabelFragment_Parent{}
 	}
-	return t.Abstract
+	return t.En
 }
-func (t *MediathekEntries_MediathekEntries) GetBase() *MediathekBaseFragment {
+func (t *ThemaLabelFragment_Parent) GetID() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries{}
+		t = &ThemaLabelFragment_Parent{}
 	}
-	return t.Base
+	return t.ID
 }
-func (t *MediathekEntries_MediathekEntries) GetExtra() []*KeyValueFragment {
+
+type FacetValueStringFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries{}
+		t = &FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Extra
+	return t.De
 }
-func (t *MediathekEntries_MediathekEntries) GetID() string {
+func (t *FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries{}
+		t = &FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
 	}
 	return t.ID
 }
-func (t *MediathekEntries_MediathekEntries) GetMedia() []*MediaListFragment {
+
+type FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries{}
+		t = &FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Media
+	return t.De
 }
-func (t *MediathekEntries_MediathekEntries) GetNotes() []*NoteFragment {
+func (t *FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries{}
+		t = &FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Notes
+	return t.En
 }
-func (t *MediathekEntries_MediathekEntries) GetReferencesFull() []*MediathekBaseFragment {
+func (t *FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
 	if t == nil {
-		t = &MediathekEntries_MediathekEntries{}
+		t = &FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.ReferencesFull
+	return t.ID
 }
 
-type Search_Search_Edges_Base_MediathekBaseFragment_ACL struct {
-	Groups []string "json:\"groups\" graphql:\"groups\""
-	Name   string   "json:\"name\" graphql:\"name\""
+type FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
 }
 
-func (t *Search_Search_Edges_Base_MediathekBaseFragment_ACL) GetGroups() []string {
+func (t *FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
 	if t == nil {
-		t = &Search_Search_Edges_Base_MediathekBaseFragment_ACL{}
+		t = &FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Groups
+	return t.De
 }
-func (t *Search_Search_Edges_Base_MediathekBaseFragment_ACL) GetName() string {
+func (t *FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
 	if t == nil {
-		t = &Search_Search_Edges_Base_MediathekBaseFragment_ACL{}
+		t = &FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Name
+	return t.En
+}
+func (t *FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
 }
 
-type Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL struct {
-	Groups []string "json:\"groups\" graphql:\"groups\""
-	Name   string   "json:\"name\" graphql:\"name\""
+type FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
 }
 
-func (t *Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL) GetGroups() []string {
+func (t *FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
 	if t == nil {
-		t = &Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL{}
+		t = &FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Groups
+	return t.De
 }
-func (t *Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL) GetName() string {
+func (t *FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
 	if t == nil {
-		t = &Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL{}
+		t = &FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Name
-}
-
-type Search_Search_Edges struct {
-	Typename       *string                  "json:\"__typename,omitempty\" graphql:\"__typename\""
-	Abstract       []*MultiLangFragment     "json:\"abstract,omitempty\" graphql:\"abstract\""
-	Base           *MediathekBaseFragment   "json:\"base\" graphql:\"base\""
-	Extra          []*KeyValueFragment      "json:\"extra,omitempty\" graphql:\"extra\""
-	ID             string                   "json:\"id\" graphql:\"id\""
-	Media          []*MediaListFragment     "json:\"media,omitempty\" graphql:\"media\""
-	Notes          []*NoteFragment          "json:\"notes,omitempty\" graphql:\"notes\""
-	ReferencesFull []*MediathekBaseFragment "json:\"referencesFull,omitempty\" graphql:\"referencesFull\""
+	return t.En
 }
-
-func (t *Search_Search_Edges) GetTypename() *string {
+func (t *FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
 	if t == nil {
-		t = &Search_Search_Edges{}
+		t = &FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Typename
+	return t.ID
 }
-func (t *Search_Search_Edges) GetAbstract() []*MultiLangFragment {
-	if t == nil {
-		t = &Search_Search_Edges{}
-	}
-	return t.Abstract
+
+type FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
 }
-func (t *Search_Search_Edges) GetBase() *MediathekBaseFragment {
+
+func (t *FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
 	if t == nil {
-		t = &Search_Search_Edges{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Base
+	return t.De
 }
-func (t *Search_Search_Edges) GetExtra() []*KeyValueFragment {
+func (t *FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
 	if t == nil {
-		t = &Search_Search_Edges{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Extra
+	return t.En
 }
-func (t *Search_Search_Edges) GetID() string {
+func (t *FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
 	if t == nil {
-		t = &Search_Search_Edges{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
 	return t.ID
 }
-func (t *Search_Search_Edges) GetMedia() []*MediaListFragment {
+
+type FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
 	if t == nil {
-		t = &Search_Search_Edges{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Media
+	return t.De
 }
-func (t *Search_Search_Edges) GetNotes() []*NoteFragment {
+func (t *FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
 	if t == nil {
-		t = &Search_Search_Edges{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.Notes
+	return t.En
 }
-func (t *Search_Search_Edges) GetReferencesFull() []*MediathekBaseFragment {
+func (t *FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
 	if t == nil {
-		t = &Search_Search_Edges{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
 	}
-	return t.ReferencesFull
+	return t.ID
 }
 
-type Search_Search struct {
-	Typename   *string                "json:\"__typename,omitempty\" graphql:\"__typename\""
-	Edges      []*Search_Search_Edges "json:\"edges\" graphql:\"edges\""
-	Facets     []*FacetFragment       "json:\"facets\" graphql:\"facets\""
-	PageInfo   *PageInfoFragment      "json:\"pageInfo\" graphql:\"pageInfo\""
-	TotalCount int64                  "json:\"totalCount\" graphql:\"totalCount\""
+type FacetValueFragment_FacetValueHierarchy_Children struct {
+	Children []*FacetValueHierarchyFragment "json:\"children\" graphql:\"children\""
+	Count    int64                          "json:\"count\" graphql:\"count\""
+	Label    string                         "json:\"label\" graphql:\"label\""
+	Path     string                         "json:\"path\" graphql:\"path\""
+	Thema    *ThemaLabelFragment            "json:\"thema,omitempty\" graphql:\"thema\""
 }
 
-func (t *Search_Search) GetTypename() *string {
+func (t *FacetValueFragment_FacetValueHierarchy_Children) GetChildren() []*FacetValueHierarchyFragment {
 	if t == nil {
-		t = &Search_Search{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children{}
 	}
-	return t.Typename
+	return t.Children
 }
-func (t *Search_Search) GetEdges() []*Search_Search_Edges {
+func (t *FacetValueFragment_FacetValueHierarchy_Children) GetCount() int64 {
 	if t == nil {
-		t = &Search_Search{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children{}
 	}
-	return t.Edges
+	return t.Count
 }
-func (t *Search_Search) GetFacets() []*FacetFragment {
+func (t *FacetValueFragment_FacetValueHierarchy_Children) GetLabel() string {
 	if t == nil {
-		t = &Search_Search{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children{}
 	}
-	return t.Facets
+	return t.Label
 }
-func (t *Search_Search) GetPageInfo() *PageInfoFragment {
+func (t *FacetValueFragment_FacetValueHierarchy_Children) GetPath() string {
 	if t == nil {
-		t = &Search_Search{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children{}
 	}
-	return t.PageInfo
+	return t.Path
 }
-func (t *Search_Search) GetTotalCount() int64 {
+func (t *FacetValueFragment_FacetValueHierarchy_Children) GetThema() *ThemaLabelFragment {
 	if t == nil {
-		t = &Search_Search{}
+		t = &FacetValueFragment_FacetValueHierarchy_Children{}
 	}
-	return t.TotalCount
+	return t.Thema
+}
+
+type FacetValueFragment_FacetValueHierarchy struct {
+	Children []*FacetValueFragment_FacetValueHierarchy_Children "json:\"children\" graphql:\"children\""
+	Path     string                                             "json:\"path\" graphql:\"path\""
+	Label    string                                             "json:\"label\" graphql:\"label\""
+	Count    int64                                              "json:\"count\" graphql:\"count\""
+	Thema    *ThemaLabelFragment                                "json:\"thema,omitempty\" graphql:\"thema\""
+}
+
+func (t *FacetValueFragment_FacetValueHierarchy) GetChildren() []*FacetValueFragment_FacetValueHierarchy_Children {
+	if t == nil {
+		t = &FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Children
+}
+func (t *FacetValueFragment_FacetValueHierarchy) GetPath() string {
+	if t == nil {
+		t = &FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Path
+}
+func (t *FacetValueFragment_FacetValueHierarchy) GetLabel() string {
+	if t == nil {
+		t = &FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Label
+}
+func (t *FacetValueFragment_FacetValueHierarchy) GetCount() int64 {
+	if t == nil {
+		t = &FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Count
+}
+func (t *FacetValueFragment_FacetValueHierarchy) GetThema() *ThemaLabelFragment {
+	if t == nil {
+		t = &FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Thema
+}
+
+type FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children struct {
+	Children []*FacetValueHierarchyFragment "json:\"children\" graphql:\"children\""
+	Count    int64                          "json:\"count\" graphql:\"count\""
+	Label    string                         "json:\"label\" graphql:\"label\""
+	Path     string                         "json:\"path\" graphql:\"path\""
+	Thema    *ThemaLabelFragment            "json:\"thema,omitempty\" graphql:\"thema\""
+}
+
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetChildren() []*FacetValueHierarchyFragment {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Children
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetCount() int64 {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Count
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetLabel() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Label
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetPath() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Path
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetThema() *ThemaLabelFragment {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Thema
+}
+
+type FacetFragment_Values_FacetValueFragment_FacetValueHierarchy struct {
+	Children []*FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children "json:\"children\" graphql:\"children\""
+	Path     string                                                                  "json:\"path\" graphql:\"path\""
+	Label    string                                                                  "json:\"label\" graphql:\"label\""
+	Count    int64                                                                   "json:\"count\" graphql:\"count\""
+	Thema    *ThemaLabelFragment                                                     "json:\"thema,omitempty\" graphql:\"thema\""
+}
+
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetChildren() []*FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Children
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetPath() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Path
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetLabel() string {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Label
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetCount() int64 {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Count
+}
+func (t *FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetThema() *ThemaLabelFragment {
+	if t == nil {
+		t = &FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Thema
+}
+
+type Autocomplete_Autocomplete struct {
+	Count int64  "json:\"count\" graphql:\"count\""
+	Type  string "json:\"type\" graphql:\"type\""
+	Value string "json:\"value\" graphql:\"value\""
+}
+
+func (t *Autocomplete_Autocomplete) GetCount() int64 {
+	if t == nil {
+		t = &Autocomplete_Autocomplete{}
+	}
+	return t.Count
+}
+func (t *Autocomplete_Autocomplete) GetType() string {
+	if t == nil {
+		t = &Autocomplete_Autocomplete{}
+	}
+	return t.Type
+}
+func (t *Autocomplete_Autocomplete) GetValue() string {
+	if t == nil {
+		t = &Autocomplete_Autocomplete{}
+	}
+	return t.Value
+}
+
+type MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL struct {
+	Groups []string "json:\"groups\" graphql:\"groups\""
+	Name   string   "json:\"name\" graphql:\"name\""
+}
+
+func (t *MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL) GetGroups() []string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL{}
+	}
+	return t.Groups
+}
+func (t *MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL) GetName() string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_Base_MediathekBaseFragment_ACL{}
+	}
+	return t.Name
+}
+
+type MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL struct {
+	Groups []string "json:\"groups\" graphql:\"groups\""
+	Name   string   "json:\"name\" graphql:\"name\""
+}
+
+func (t *MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL) GetGroups() []string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL{}
+	}
+	return t.Groups
+}
+func (t *MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL) GetName() string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries_ReferencesFull_MediathekBaseFragment_ACL{}
+	}
+	return t.Name
+}
+
+type MediathekEntries_MediathekEntries struct {
+	Typename       *string                  "json:\"__typename,omitempty\" graphql:\"__typename\""
+	Abstract       []*MultiLangFragment     "json:\"abstract,omitempty\" graphql:\"abstract\""
+	Base           *MediathekBaseFragment   "json:\"base\" graphql:\"base\""
+	Extra          []*KeyValueFragment      "json:\"extra,omitempty\" graphql:\"extra\""
+	ID             string                   "json:\"id\" graphql:\"id\""
+	Media          []*MediaListFragment     "json:\"media,omitempty\" graphql:\"media\""
+	Notes          []*NoteFragment          "json:\"notes,omitempty\" graphql:\"notes\""
+	ReferencesFull []*MediathekBaseFragment "json:\"referencesFull,omitempty\" graphql:\"referencesFull\""
+}
+
+func (t *MediathekEntries_MediathekEntries) GetTypename() *string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries{}
+	}
+	return t.Typename
+}
+func (t *MediathekEntries_MediathekEntries) GetAbstract() []*MultiLangFragment {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries{}
+	}
+	return t.Abstract
+}
+func (t *MediathekEntries_MediathekEntries) GetBase() *MediathekBaseFragment {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries{}
+	}
+	return t.Base
+}
+func (t *MediathekEntries_MediathekEntries) GetExtra() []*KeyValueFragment {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries{}
+	}
+	return t.Extra
+}
+func (t *MediathekEntries_MediathekEntries) GetID() string {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries{}
+	}
+	return t.ID
+}
+func (t *MediathekEntries_MediathekEntries) GetMedia() []*MediaListFragment {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries{}
+	}
+	return t.Media
+}
+func (t *MediathekEntries_MediathekEntries) GetNotes() []*NoteFragment {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries{}
+	}
+	return t.Notes
+}
+func (t *MediathekEntries_MediathekEntries) GetReferencesFull() []*MediathekBaseFragment {
+	if t == nil {
+		t = &MediathekEntries_MediathekEntries{}
+	}
+	return t.ReferencesFull
+}
+
+type Related_Related_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Related_Related_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &Related_Related_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *Related_Related_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &Related_Related_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *Related_Related_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &Related_Related_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type Related_Related_MediathekBaseFragment_ACL struct {
+	Groups []string "json:\"groups\" graphql:\"groups\""
+	Name   string   "json:\"name\" graphql:\"name\""
+}
+
+func (t *Related_Related_MediathekBaseFragment_ACL) GetGroups() []string {
+	if t == nil {
+		t = &Related_Related_MediathekBaseFragment_ACL{}
+	}
+	return t.Groups
+}
+func (t *Related_Related_MediathekBaseFragment_ACL) GetName() string {
+	if t == nil {
+		t = &Related_Related_MediathekBaseFragment_ACL{}
+	}
+	return t.Name
+}
+
+type Search_Search_Edges_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_Edges_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &Search_Search_Edges_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *Search_Search_Edges_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &Search_Search_Edges_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *Search_Search_Edges_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &Search_Search_Edges_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type Search_Search_Edges_Base_MediathekBaseFragment_ACL struct {
+	Groups []string "json:\"groups\" graphql:\"groups\""
+	Name   string   "json:\"name\" graphql:\"name\""
+}
+
+func (t *Search_Search_Edges_Base_MediathekBaseFragment_ACL) GetGroups() []string {
+	if t == nil {
+		t = &Search_Search_Edges_Base_MediathekBaseFragment_ACL{}
+	}
+	return t.Groups
+}
+func (t *Search_Search_Edges_Base_MediathekBaseFragment_ACL) GetName() string {
+	if t == nil {
+		t = &Search_Search_Edges_Base_MediathekBaseFragment_ACL{}
+	}
+	return t.Name
+}
+
+type Search_Search_Edges_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_Edges_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &Search_Search_Edges_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *Search_Search_Edges_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &Search_Search_Edges_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *Search_Search_Edges_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &Search_Search_Edges_ReferencesFull_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL struct {
+	Groups []string "json:\"groups\" graphql:\"groups\""
+	Name   string   "json:\"name\" graphql:\"name\""
+}
+
+func (t *Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL) GetGroups() []string {
+	if t == nil {
+		t = &Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL{}
+	}
+	return t.Groups
+}
+func (t *Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL) GetName() string {
+	if t == nil {
+		t = &Search_Search_Edges_ReferencesFull_MediathekBaseFragment_ACL{}
+	}
+	return t.Name
+}
+
+type Search_Search_Edges_Highlight struct {
+	Field     string   "json:\"field\" graphql:\"field\""
+	Fragments []string "json:\"fragments\" graphql:\"fragments\""
+}
+
+func (t *Search_Search_Edges_Highlight) GetField() string {
+	if t == nil {
+		t = &Search_Search_Edges_Highlight{}
+	}
+	return t.Field
+}
+func (t *Search_Search_Edges_Highlight) GetFragments() []string {
+	if t == nil {
+		t = &Search_Search_Edges_Highlight{}
+	}
+	return t.Fragments
+}
+
+type Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_CategoryThema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_ACL struct {
+	Groups []string "json:\"groups\" graphql:\"groups\""
+	Name   string   "json:\"name\" graphql:\"name\""
+}
+
+func (t *Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_ACL) GetGroups() []string {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_ACL{}
+	}
+	return t.Groups
+}
+func (t *Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_ACL) GetName() string {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse_Hits_Base_MediathekBaseFragment_ACL{}
+	}
+	return t.Name
+}
+
+type Search_Search_Edges_Collapse_Hits struct {
+	Base *MediathekBaseFragment "json:\"base\" graphql:\"base\""
+	ID   string                 "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_Edges_Collapse_Hits) GetBase() *MediathekBaseFragment {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse_Hits{}
+	}
+	return t.Base
+}
+func (t *Search_Search_Edges_Collapse_Hits) GetID() string {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse_Hits{}
+	}
+	return t.ID
+}
+
+type Search_Search_Edges_Collapse struct {
+	Hits       []*Search_Search_Edges_Collapse_Hits "json:\"hits\" graphql:\"hits\""
+	TotalCount int64                                "json:\"totalCount\" graphql:\"totalCount\""
+	Value      string                               "json:\"value\" graphql:\"value\""
+}
+
+func (t *Search_Search_Edges_Collapse) GetHits() []*Search_Search_Edges_Collapse_Hits {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse{}
+	}
+	return t.Hits
+}
+func (t *Search_Search_Edges_Collapse) GetTotalCount() int64 {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse{}
+	}
+	return t.TotalCount
+}
+func (t *Search_Search_Edges_Collapse) GetValue() string {
+	if t == nil {
+		t = &Search_Search_Edges_Collapse{}
+	}
+	return t.Value
+}
+
+type Search_Search_Edges struct {
+	Typename       *string                          "json:\"__typename,omitempty\" graphql:\"__typename\""
+	Abstract       []*MultiLangFragment             "json:\"abstract,omitempty\" graphql:\"abstract\""
+	Base           *MediathekBaseFragment           "json:\"base\" graphql:\"base\""
+	Collapse       *Search_Search_Edges_Collapse    "json:\"collapse,omitempty\" graphql:\"collapse\""
+	Explanation    *string                          "json:\"explanation,omitempty\" graphql:\"explanation\""
+	Extra          []*KeyValueFragment              "json:\"extra,omitempty\" graphql:\"extra\""
+	Highlight      []*Search_Search_Edges_Highlight "json:\"highlight,omitempty\" graphql:\"highlight\""
+	ID             string                           "json:\"id\" graphql:\"id\""
+	Media          []*MediaListFragment             "json:\"media,omitempty\" graphql:\"media\""
+	Notes          []*NoteFragment                  "json:\"notes,omitempty\" graphql:\"notes\""
+	ReferencesFull []*MediathekBaseFragment         "json:\"referencesFull,omitempty\" graphql:\"referencesFull\""
+}
+
+func (t *Search_Search_Edges) GetTypename() *string {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.Typename
+}
+func (t *Search_Search_Edges) GetAbstract() []*MultiLangFragment {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.Abstract
+}
+func (t *Search_Search_Edges) GetBase() *MediathekBaseFragment {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.Base
+}
+func (t *Search_Search_Edges) GetCollapse() *Search_Search_Edges_Collapse {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.Collapse
+}
+func (t *Search_Search_Edges) GetExplanation() *string {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.Explanation
+}
+func (t *Search_Search_Edges) GetExtra() []*KeyValueFragment {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.Extra
+}
+func (t *Search_Search_Edges) GetHighlight() []*Search_Search_Edges_Highlight {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.Highlight
+}
+func (t *Search_Search_Edges) GetID() string {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.ID
+}
+func (t *Search_Search_Edges) GetMedia() []*MediaListFragment {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.Media
+}
+func (t *Search_Search_Edges) GetNotes() []*NoteFragment {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.Notes
+}
+func (t *Search_Search_Edges) GetReferencesFull() []*MediathekBaseFragment {
+	if t == nil {
+		t = &Search_Search_Edges{}
+	}
+	return t.ReferencesFull
+}
+
+type Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueString_FacetValueStringFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent struct {
+	De string "json:\"de\" graphql:\"de\""
+	En string "json:\"en\" graphql:\"en\""
+	ID string "json:\"id\" graphql:\"id\""
+}
+
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetDe() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.De
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetEn() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.En
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent) GetID() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children_Children_FacetValueHierarchyFragment_Thema_ThemaLabelFragment_Parent{}
+	}
+	return t.ID
+}
+
+type Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children struct {
+	Children []*FacetValueHierarchyFragment "json:\"children\" graphql:\"children\""
+	Count    int64                          "json:\"count\" graphql:\"count\""
+	Label    string                         "json:\"label\" graphql:\"label\""
+	Path     string                         "json:\"path\" graphql:\"path\""
+	Thema    *ThemaLabelFragment            "json:\"thema,omitempty\" graphql:\"thema\""
+}
+
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetChildren() []*FacetValueHierarchyFragment {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Children
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetCount() int64 {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Count
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetLabel() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Label
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetPath() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Path
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children) GetThema() *ThemaLabelFragment {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children{}
+	}
+	return t.Thema
+}
+
+type Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy struct {
+	Children []*Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children "json:\"children\" graphql:\"children\""
+	Path     string                                                                                       "json:\"path\" graphql:\"path\""
+	Label    string                                                                                       "json:\"label\" graphql:\"label\""
+	Count    int64                                                                                        "json:\"count\" graphql:\"count\""
+	Thema    *ThemaLabelFragment                                                                          "json:\"thema,omitempty\" graphql:\"thema\""
+}
+
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetChildren() []*Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy_Children {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Children
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetPath() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Path
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetLabel() string {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Label
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetCount() int64 {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Count
+}
+func (t *Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy) GetThema() *ThemaLabelFragment {
+	if t == nil {
+		t = &Search_Search_Facets_FacetFragment_Values_FacetValueFragment_FacetValueHierarchy{}
+	}
+	return t.Thema
+}
+
+type Search_Search_Suggestions struct {
+	Highlighted *string "json:\"highlighted,omitempty\" graphql:\"highlighted\""
+	Score       float64 "json:\"score\" graphql:\"score\""
+	Text        string  "json:\"text\" graphql:\"text\""
+}
+
+func (t *Search_Search_Suggestions) GetHighlighted() *string {
+	if t == nil {
+		t = &Search_Search_Suggestions{}
+	}
+	return t.Highlighted
+}
+func (t *Search_Search_Suggestions) GetScore() float64 {
+	if t == nil {
+		t = &Search_Search_Suggestions{}
+	}
+	return t.Score
+}
+func (t *Search_Search_Suggestions) GetText() string {
+	if t == nil {
+		t = &Search_Search_Suggestions{}
+	}
+	return t.Text
+}
+
+type Search_Search struct {
+	Typename       *string                      "json:\"__typename,omitempty\" graphql:\"__typename\""
+	CorrectedQuery *string                      "json:\"correctedQuery,omitempty\" graphql:\"correctedQuery\""
+	Edges          []*Search_Search_Edges       "json:\"edges\" graphql:\"edges\""
+	Facets         []*FacetFragment             "json:\"facets\" graphql:\"facets\""
+	PageInfo       *PageInfoFragment            "json:\"pageInfo\" graphql:\"pageInfo\""
+	Request        *string                      "json:\"request,omitempty\" graphql:\"request\""
+	Suggestions    []*Search_Search_Suggestions "json:\"suggestions,omitempty\" graphql:\"suggestions\""
+	TotalCount     int64                        "json:\"totalCount\" graphql:\"totalCount\""
+}
+
+func (t *Search_Search) GetTypename() *string {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return t.Typename
+}
+func (t *Search_Search) GetCorrectedQuery() *string {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return t.CorrectedQuery
+}
+func (t *Search_Search) GetEdges() []*Search_Search_Edges {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return t.Edges
+}
+func (t *Search_Search) GetFacets() []*FacetFragment {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return t.Facets
+}
+func (t *Search_Search) GetPageInfo() *PageInfoFragment {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return t.PageInfo
+}
+func (t *Search_Search) GetRequest() *string {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return t.Request
+}
+func (t *Search_Search) GetSuggestions() []*Search_Search_Suggestions {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return t.Suggestions
+}
+func (t *Search_Search) GetTotalCount() int64 {
+	if t == nil {
+		t = &Search_Search{}
+	}
+	return t.TotalCount
+}
+
+type SearchInEntry_SearchInEntry_Media struct {
+	FulltextLength int64    "json:\"fulltextLength\" graphql:\"fulltextLength\""
+	Height         int64    "json:\"height\" graphql:\"height\""
+	Matched        bool     "json:\"matched\" graphql:\"matched\""
+	Mimetype       string   "json:\"mimetype\" graphql:\"mimetype\""
+	Name           string   "json:\"name\" graphql:\"name\""
+	Orientation    int64    "json:\"orientation\" graphql:\"orientation\""
+	Pronom         *string  "json:\"pronom,omitempty\" graphql:\"pronom\""
+	Score          *float64 "json:\"score,omitempty\" graphql:\"score\""
+	Snippets       []string "json:\"snippets,omitempty\" graphql:\"snippets\""
+	Type           string   "json:\"type\" graphql:\"type\""
+	URI            string   "json:\"uri\" graphql:\"uri\""
+	Width          int64    "json:\"width\" graphql:\"width\""
+}
+
+func (t *SearchInEntry_SearchInEntry_Media) GetFulltextLength() int64 {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.FulltextLength
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetHeight() int64 {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Height
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetMatched() bool {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Matched
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetMimetype() string {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Mimetype
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetName() string {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Name
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetOrientation() int64 {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Orientation
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetPronom() *string {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Pronom
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetScore() *float64 {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Score
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetSnippets() []string {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Snippets
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetType() string {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Type
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetURI() string {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.URI
+}
+func (t *SearchInEntry_SearchInEntry_Media) GetWidth() int64 {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry_Media{}
+	}
+	return t.Width
+}
+
+type SearchInEntry_SearchInEntry struct {
+	Index     int64                             "json:\"index\" graphql:\"index\""
+	Media     SearchInEntry_SearchInEntry_Media "json:\"media\" graphql:\"media\""
+	Positions []int64                           "json:\"positions\" graphql:\"positions\""
+	Score     *float64                          "json:\"score,omitempty\" graphql:\"score\""
+	Snippets  []string                          "json:\"snippets\" graphql:\"snippets\""
+	Type      string                            "json:\"type\" graphql:\"type\""
+}
+
+func (t *SearchInEntry_SearchInEntry) GetIndex() int64 {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry{}
+	}
+	return t.Index
+}
+func (t *SearchInEntry_SearchInEntry) GetMedia() *SearchInEntry_SearchInEntry_Media {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry{}
+	}
+	return &t.Media
+}
+func (t *SearchInEntry_SearchInEntry) GetPositions() []int64 {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry{}
+	}
+	return t.Positions
+}
+func (t *SearchInEntry_SearchInEntry) GetScore() *float64 {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry{}
+	}
+	return t.Score
+}
+func (t *SearchInEntry_SearchInEntry) GetSnippets() []string {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry{}
+	}
+	return t.Snippets
+}
+func (t *SearchInEntry_SearchInEntry) GetType() string {
+	if t == nil {
+		t = &SearchInEntry_SearchInEntry{}
+	}
+	return t.Type
+}
+
+type ThemaTree_ThemaTree struct {
+	Children []*ThemaNodeFragment "json:\"children\" graphql:\"children\""
+	Count    int64                "json:\"count\" graphql:\"count\""
+	De       string               "json:\"de\" graphql:\"de\""
+	En       string               "json:\"en\" graphql:\"en\""
+	ID       string               "json:\"id\" graphql:\"id\""
+}
+
+func (t *ThemaTree_ThemaTree) GetChildren() []*ThemaNodeFragment {
+	if t == nil {
+		t = &ThemaTree_ThemaTree{}
+	}
+	return t.Children
+}
+func (t *ThemaTree_ThemaTree) GetCount() int64 {
+	if t == nil {
+		t = &ThemaTree_ThemaTree{}
+	}
+	return t.Count
+}
+func (t *ThemaTree_ThemaTree) GetDe() string {
+	if t == nil {
+		t = &ThemaTree_ThemaTree{}
+	}
+	return t.De
+}
+func (t *ThemaTree_ThemaTree) GetEn() string {
+	if t == nil {
+		t = &ThemaTree_ThemaTree{}
+	}
+	return t.En
+}
+func (t *ThemaTree_ThemaTree) GetID() string {
+	if t == nil {
+		t = &ThemaTree_ThemaTree{}
+	}
+	return t.ID
+}
+
+type Autocomplete struct {
+	Autocomplete []*Autocomplete_Autocomplete "json:\"autocomplete\" graphql:\"autocomplete\""
+}
+
+func (t *Autocomplete) GetAutocomplete() []*Autocomplete_Autocomplete {
+	if t == nil {
+		t = &Autocomplete{}
+	}
+	return t.Autocomplete
 }
 
 type MediathekEntries struct {
@@ -803,6 +2163,17 @@ func (t *MediathekEntries) GetMediathekEntries() []*MediathekEntries_MediathekEn
 	return t.MediathekEntries
 }
 
+type Related struct {
+	Related []*MediathekBaseFragment "json:\"related\" graphql:\"related\""
+}
+
+func (t *Related) GetRelated() []*MediathekBaseFragment {
+	if t == nil {
+		t = &Related{}
+	}
+	return t.Related
+}
+
 type Search struct {
 	Search Search_Search "json:\"search\" graphql:\"search\""
 }
@@ -814,6 +2185,67 @@ func (t *Search) GetSearch() *Search_Search {
 	return &t.Search
 }
 
+type SearchInEntry struct {
+	SearchInEntry []*SearchInEntry_SearchInEntry "json:\"searchInEntry\" graphql:\"searchInEntry\""
+}
+
+func (t *SearchInEntry) GetSearchInEntry() []*SearchInEntry_SearchInEntry {
+	if t == nil {
+		t = &SearchInEntry{}
+	}
+	return t.SearchInEntry
+}
+
+type SearchRequest struct {
+	SearchRequest string "json:\"searchRequest\" graphql:\"searchRequest\""
+}
+
+func (t *SearchRequest) GetSearchRequest() string {
+	if t == nil {
+		t = &SearchRequest{}
+	}
+	return t.SearchRequest
+}
+
+type ThemaTree struct {
+	ThemaTree []*ThemaTree_ThemaTree "json:\"themaTree\" graphql:\"themaTree\""
+}
+
+func (t *ThemaTree) GetThemaTree() []*ThemaTree_ThemaTree {
+	if t == nil {
+		t = &ThemaTree{}
+	}
+	return t.ThemaTree
+}
+
+const AutocompleteDocument = `query Autocomplete ($prefix: String!, $fields: [String!], $size: Int!) {
+	autocomplete(prefix: $prefix, fields: $fields, size: $size) {
+		type
+		value
+		count
+	}
+}
+`
+
+func (c *Client) Autocomplete(ctx context.Context, prefix string, fields []string, size int64, interceptors ...clientv2.RequestInterceptor) (*Autocomplete, error) {
+	vars := map[string]any{
+		"prefix": prefix,
+		"fields": fields,
+		"size":   size,
+	}
+
+	var res Autocomplete
+	if err := c.Client.Post(ctx, "Autocomplete", AutocompleteDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 const MediathekEntriesDocument = `query MediathekEntries ($signatures: [String!]!) {
 	mediathekEntries(signatures: $signatures) {
 		id
@@ -851,7 +2283,13 @@ fragment MediathekBaseFragment on MediathekBaseEntry {
 	series
 	place
 	date
+	dateStart
+	dateEnd
+	datePrecision
 	category
+	categoryThema {
+		... ThemaLabelFragment
+	}
 	tags
 	url
 	publisher
@@ -895,6 +2333,16 @@ fragment PersonIdentifierFragment on PersonIdentifier {
 	url
 	additional
 }
+fragment ThemaLabelFragment on ThemaLabel {
+	id
+	de
+	en
+	parent {
+		id
+		de
+		en
+	}
+}
 fragment MediaCountFragment on MediaCount {
 	type
 	count
@@ -908,6 +2356,9 @@ fragment MediaItemFragment on Media {
 	orientation
 	width
 	height
+	matched
+	score
+	snippets
 }
 fragment ReferenceFragment on Reference {
 	type
@@ -947,8 +2398,128 @@ func (c *Client) MediathekEntries(ctx context.Context, signatures []string, inte
 	return &res, nil
 }
 
-const SearchDocument = `query search ($searchtype: String!, $query: String!, $facets: [InFacet!], $filter: [InFilter!], $vector: [Float!], $first: Int, $size: Int, $cursor: String, $sort: [SortField!]) {
-	search(searchtype: $searchtype, query: $query, facets: $facets, filter: $filter, vector: $vector, first: $first, size: $size, cursor: $cursor, sort: $sort) {
+const RelatedDocument = `query Related ($signature: String!, $size: Int!) {
+	related(signature: $signature, size: $size) {
+		... MediathekBaseFragment
+	}
+}
+fragment MediathekBaseFragment on MediathekBaseEntry {
+	signature
+	collectionTitle
+	source
+	title {
+		... MultiLangFragment
+	}
+	person {
+		... PersonFragment
+	}
+	series
+	place
+	date
+	dateStart
+	dateEnd
+	datePrecision
+	category
+	categoryThema {
+		... ThemaLabelFragment
+	}
+	tags
+	url
+	publisher
+	rights
+	license
+	type
+	mediaCount {
+		... MediaCountFragment
+	}
+	mediaVisible
+	mediaProtected
+	poster {
+		... MediaItemFragment
+	}
+	references {
+		... ReferenceFragment
+	}
+	acl {
+		name
+		groups
+	}
+}
+fragment MultiLangFragment on MultiLangString {
+	lang
+	value
+	translated
+}
+fragment PersonFragment on Person {
+	name
+	role
+	alternativeNames
+	year
+	web
+	identifier {
+		... PersonIdentifierFragment
+	}
+}
+fragment PersonIdentifierFragment on PersonIdentifier {
+	name
+	id
+	url
+	additional
+}
+fragment ThemaLabelFragment on ThemaLabel {
+	id
+	de
+	en
+	parent {
+		id
+		de
+		en
+	}
+}
+fragment MediaCountFragment on MediaCount {
+	type
+	count
+}
+fragment MediaItemFragment on Media {
+	name
+	mimetype
+	pronom
+	type
+	uri
+	orientation
+	width
+	height
+	matched
+	score
+	snippets
+}
+fragment ReferenceFragment on Reference {
+	type
+	title
+	signature
+}
+`
+
+func (c *Client) Related(ctx context.Context, signature string, size int64, interceptors ...clientv2.RequestInterceptor) (*Related, error) {
+	vars := map[string]any{
+		"signature": signature,
+		"size":      size,
+	}
+
+	var res Related
+	if err := c.Client.Post(ctx, "Related", RelatedDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SearchDocument = `query search ($searchtype: String!, $query: String!, $advancedQuery: InAdvancedQuery, $facets: [InFacet!], $filter: [InFilter!], $vector: [Float!], $vectorOptions: InVectorOptions, $first: Int, $size: Int, $cursor: String, $sort: [SortField!], $autoCorrect: Boolean!, $explain: Boolean!, $collapse: InCollapse) {
+	search(searchtype: $searchtype, query: $query, advancedQuery: $advancedQuery, facets: $facets, filter: $filter, vector: $vector, vectorOptions: $vectorOptions, first: $first, size: $size, cursor: $cursor, sort: $sort, autoCorrect: $autoCorrect, explain: $explain, collapse: $collapse) {
 		totalCount
 		pageInfo {
 			... PageInfoFragment
@@ -973,11 +2544,33 @@ const SearchDocument = `query search ($searchtype: String!, $query: String!, $fa
 			referencesFull {
 				... MediathekBaseFragment
 			}
+			highlight {
+				field
+				fragments
+			}
+			explanation
+			collapse {
+				value
+				totalCount
+				hits {
+					id
+					base {
+						... MediathekBaseFragment
+					}
+				}
+			}
 			__typename
 		}
 		facets {
 			... FacetFragment
 		}
+		suggestions {
+			text
+			highlighted
+			score
+		}
+		correctedQuery
+		request
 		__typename
 	}
 }
@@ -1001,7 +2594,13 @@ fragment MediathekBaseFragment on MediathekBaseEntry {
 	series
 	place
 	date
+	dateStart
+	dateEnd
+	datePrecision
 	category
+	categoryThema {
+		... ThemaLabelFragment
+	}
 	tags
 	url
 	publisher
@@ -1045,6 +2644,16 @@ fragment PersonIdentifierFragment on PersonIdentifier {
 	url
 	additional
 }
+fragment ThemaLabelFragment on ThemaLabel {
+	id
+	de
+	en
+	parent {
+		id
+		de
+		en
+	}
+}
 fragment MediaCountFragment on MediaCount {
 	type
 	count
@@ -1058,6 +2667,9 @@ fragment MediaItemFragment on Media {
 	orientation
 	width
 	height
+	matched
+	score
+	snippets
 }
 fragment ReferenceFragment on Reference {
 	type
@@ -1091,28 +2703,62 @@ fragment FacetValueFragment on FacetValue {
 	... on FacetValueInt {
 		... FacetValueIntFragment
 	}
+	... on FacetValueRange {
+		... FacetValueRangeFragment
+	}
+	... on FacetValueHierarchy {
+		... FacetValueHierarchyFragment
+		children {
+			... FacetValueHierarchyFragment
+			children {
+				... FacetValueHierarchyFragment
+			}
+		}
+	}
 }
 fragment FacetValueStringFragment on FacetValueString {
 	strVal
 	count
+	thema {
+		... ThemaLabelFragment
+	}
 }
 fragment FacetValueIntFragment on FacetValueInt {
 	intVal
 	count
 }
+fragment FacetValueRangeFragment on FacetValueRange {
+	key
+	from
+	to
+	count
+}
+fragment FacetValueHierarchyFragment on FacetValueHierarchy {
+	path
+	label
+	count
+	thema {
+		... ThemaLabelFragment
+	}
+}
 `
 
-func (c *Client) Search(ctx context.Context, searchtype string, query string, facets []*InFacet, filter []*InFilter, vector []float64, first *int64, size *int64, cursor *string, sort []*SortField, interceptors ...clientv2.RequestInterceptor) (*Search, error) {
+func (c *Client) Search(ctx context.Context, searchtype string, query string, advancedQuery *InAdvancedQuery, facets []*InFacet, filter []*InFilter, vector []float64, vectorOptions *InVectorOptions, first *int64, size *int64, cursor *string, sort []*SortField, autoCorrect bool, explain bool, collapse *InCollapse, interceptors ...clientv2.RequestInterceptor) (*Search, error) {
 	vars := map[string]any{
-		"searchtype": searchtype,
-		"query":      query,
-		"facets":     facets,
-		"filter":     filter,
-		"vector":     vector,
-		"first":      first,
-		"size":       size,
-		"cursor":     cursor,
-		"sort":       sort,
+		"searchtype":    searchtype,
+		"query":         query,
+		"advancedQuery": advancedQuery,
+		"facets":        facets,
+		"filter":        filter,
+		"vector":        vector,
+		"vectorOptions": vectorOptions,
+		"first":         first,
+		"size":          size,
+		"cursor":        cursor,
+		"sort":          sort,
+		"autoCorrect":   autoCorrect,
+		"explain":       explain,
+		"collapse":      collapse,
 	}
 
 	var res Search
@@ -1127,7 +2773,125 @@ func (c *Client) Search(ctx context.Context, searchtype string, query string, fa
 	return &res, nil
 }
 
+const SearchInEntryDocument = `query SearchInEntry ($signature: String!, $query: String!, $size: Int!) {
+	searchInEntry(signature: $signature, query: $query, size: $size) {
+		type
+		index
+		media {
+			... MediaItemFragment
+			fulltextLength
+		}
+		score
+		snippets
+		positions
+	}
+}
+fragment MediaItemFragment on Media {
+	name
+	mimetype
+	pronom
+	type
+	uri
+	orientation
+	width
+	height
+	matched
+	score
+	snippets
+}
+`
+
+func (c *Client) SearchInEntry(ctx context.Context, signature string, query string, size int64, interceptors ...clientv2.RequestInterceptor) (*SearchInEntry, error) {
+	vars := map[string]any{
+		"signature": signature,
+		"query":     query,
+		"size":      size,
+	}
+
+	var res SearchInEntry
+	if err := c.Client.Post(ctx, "SearchInEntry", SearchInEntryDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SearchRequestDocument = `query SearchRequest ($searchtype: String!, $query: String!, $advancedQuery: InAdvancedQuery, $facets: [InFacet!], $filter: [InFilter!], $vector: [Float!], $vectorOptions: InVectorOptions, $first: Int, $size: Int, $cursor: String, $sort: [SortField!], $collapse: InCollapse) {
+	searchRequest(searchtype: $searchtype, query: $query, advancedQuery: $advancedQuery, facets: $facets, filter: $filter, vector: $vector, vectorOptions: $vectorOptions, first: $first, size: $size, cursor: $cursor, sort: $sort, collapse: $collapse)
+}
+`
+
+func (c *Client) SearchRequest(ctx context.Context, searchtype string, query string, advancedQuery *InAdvancedQuery, facets []*InFacet, filter []*InFilter, vector []float64, vectorOptions *InVectorOptions, first *int64, size *int64, cursor *string, sort []*SortField, collapse *InCollapse, interceptors ...clientv2.RequestInterceptor) (*SearchRequest, error) {
+	vars := map[string]any{
+		"searchtype":    searchtype,
+		"query":         query,
+		"advancedQuery": advancedQuery,
+		"facets":        facets,
+		"filter":        filter,
+		"vector":        vector,
+		"vectorOptions": vectorOptions,
+		"first":         first,
+		"size":          size,
+		"cursor":        cursor,
+		"sort":          sort,
+		"collapse":      collapse,
+	}
+
+	var res SearchRequest
+	if err := c.Client.Post(ctx, "SearchRequest", SearchRequestDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const ThemaTreeDocument = `query ThemaTree ($filter: [InFilter!]) {
+	themaTree(filter: $filter) {
+		... ThemaNodeFragment
+		children {
+			... ThemaNodeFragment
+		}
+	}
+}
+fragment ThemaNodeFragment on ThemaNode {
+	id
+	de
+	en
+	count
+}
+`
+
+func (c *Client) ThemaTree(ctx context.Context, filter []*InFilter, interceptors ...clientv2.RequestInterceptor) (*ThemaTree, error) {
+	vars := map[string]any{
+		"filter": filter,
+	}
+
+	var res ThemaTree
+	if err := c.Client.Post(ctx, "ThemaTree", ThemaTreeDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 var DocumentOperationNames = map[string]string{
+	AutocompleteDocument:     "Autocomplete",
 	MediathekEntriesDocument: "MediathekEntries",
+	RelatedDocument:          "Related",
 	SearchDocument:           "search",
+	SearchInEntryDocument:    "SearchInEntry",
+	SearchRequestDocument:    "SearchRequest",
+	ThemaTreeDocument:        "ThemaTree",
 }
@@ -11,11 +11,33 @@ type ACL struct {
 	Groups []string `json:"groups"`
 }
 
+type AutocompleteSuggestion struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+type CollapseGroup struct {
+	Value      string                `json:"value"`
+	TotalCount int64                 `json:"totalCount"`
+	Hits       []*MediathekFullEntry `json:"hits"`
+}
+
 type Facet struct {
 	Name   string       `json:"name"`
 	Values []FacetValue `json:"values,omitempty"`
 }
 
+type FacetValueHierarchy struct {
+	Path     string                 `json:"path"`
+	Label    string                 `json:"label"`
+	Count    int64                  `json:"count"`
+	Thema    *ThemaLabel            `json:"thema,omitempty"`
+	Children []*FacetValueHierarchy `json:"children"`
+}
+
+func (FacetValueHierarchy) IsFacetValue() {}
+
 type FacetValueInt struct {
 	IntVal int64 `json:"intVal"`
 	Count  int64 `json:"count"`
@@ -23,16 +45,83 @@ type FacetValueInt struct {
 
 func (FacetValueInt) IsFacetValue() {}
 
+type FacetValueRange struct {
+	Key   string  `json:"key"`
+	From  *string `json:"from,omitempty"`
+	To    *string `json:"to,omitempty"`
+	Count int64   `json:"count"`
+}
+
+func (FacetValueRange) IsFacetValue() {}
+
 type FacetValueString struct {
-	StrVal string `json:"strVal"`
-	Count  int64  `json:"count"`
+	StrVal string      `json:"strVal"`
+	Count  int64       `json:"count"`
+	Thema  *ThemaLabel `json:"thema,omitempty"`
 }
 
 func (FacetValueString) IsFacetValue() {}
 
+type Highlight struct {
+	Field     string   `json:"field"`
+	Fragments []string `json:"fragments"`
+}
+
+type InAdvancedClause struct {
+	Field    string  `json:"field"`
+	Operator string  `json:"operator"`
+	Value    *string `json:"value,omitempty"`
+	From     *string `json:"from,omitempty"`
+	To       *string `json:"to,omitempty"`
+}
+
+type InAdvancedQuery struct {
+	Operator string              `json:"operator"`
+	Clauses  []*InAdvancedClause `json:"clauses,omitempty"`
+	Groups   []*InAdvancedQuery  `json:"groups,omitempty"`
+}
+
+type InCollapse struct {
+	Field     string `json:"field"`
+	InnerHits int64  `json:"innerHits"`
+}
+
 type InFacet struct {
-	Term  *InFacetTerm `json:"term,omitempty"`
-	Query *InFilter    `json:"query"`
+	Term          *InFacetTerm          `json:"term,omitempty"`
+	DateHistogram *InFacetDateHistogram `json:"dateHistogram,omitempty"`
+	Range         *InFacetRange         `json:"range,omitempty"`
+	Hierarchy     *InFacetHierarchy     `json:"hierarchy,omitempty"`
+	Query         *InFilter             `json:"query"`
+}
+
+type InFacetDateHistogram struct {
+	Field            string  `json:"field"`
+	Name             string  `json:"name"`
+	CalendarInterval string  `json:"calendarInterval"`
+	Format           *string `json:"format,omitempty"`
+	MinDocCount      int64   `json:"minDocCount"`
+}
+
+type InFacetHierarchy struct {
+	Field       string  `json:"field"`
+	Name        string  `json:"name"`
+	Separator   string  `json:"separator"`
+	Prefix      *string `json:"prefix,omitempty"`
+	MinDocCount int64   `json:"minDocCount"`
+	Size        int64   `json:"size"`
+}
+
+type InFacetRange struct {
+	Field  string                `json:"field"`
+	Name   string                `json:"name"`
+	Date   bool                  `json:"date"`
+	Ranges []*InFacetRangeBucket `json:"ranges"`
+}
+
+type InFacetRangeBucket struct {
+	Key  *string `json:"key,omitempty"`
+	From *string `json:"from,omitempty"`
+	To   *string `json:"to,omitempty"`
 }
 
 type InFacetTerm struct {
@@ -45,8 +134,13 @@ type InFacetTerm struct {
 }
 
 type InFilter struct {
-	BoolTerm   *InFilterBoolTerm   `json:"boolTerm,omitempty"`
-	ExistsTerm *InFilterExistsTerm `json:"existsTerm,omitempty"`
+	BoolTerm      *InFilterBoolTerm      `json:"boolTerm,omitempty"`
+	ExistsTerm    *InFilterExistsTerm    `json:"existsTerm,omitempty"`
+	RangeTerm     *InFilterRangeTerm     `json:"rangeTerm,omitempty"`
+	HierarchyTerm *InFilterHierarchyTerm `json:"hierarchyTerm,omitempty"`
+	NotTerm       *InFilter              `json:"notTerm,omitempty"`
+	PrefixTerm    *InFilterPrefixTerm    `json:"prefixTerm,omitempty"`
+	WildcardTerm  *InFilterWildcardTerm  `json:"wildcardTerm,omitempty"`
 }
 
 type InFilterBoolTerm struct {
@@ -59,22 +153,58 @@ type InFilterExistsTerm struct {
 	Field string `json:"field"`
 }
 
+type InFilterHierarchyTerm struct {
+	Field     string   `json:"field"`
+	Separator string   `json:"separator"`
+	Values    []string `json:"values,omitempty"`
+}
+
+type InFilterPrefixTerm struct {
+	Field  string   `json:"field"`
+	Values []string `json:"values,omitempty"`
+}
+
+type InFilterRangeTerm struct {
+	Field       string  `json:"field"`
+	From        *string `json:"from,omitempty"`
+	To          *string `json:"to,omitempty"`
+	IncludeFrom *bool   `json:"includeFrom,omitempty"`
+	IncludeTo   *bool   `json:"includeTo,omitempty"`
+}
+
+type InFilterWildcardTerm struct {
+	Field           string   `json:"field"`
+	CaseInsensitive bool     `json:"caseInsensitive"`
+	Values          []string `json:"values,omitempty"`
+}
+
+type InVectorOptions struct {
+	Field         string   `json:"field"`
+	K             int64    `json:"k"`
+	NumCandidates int64    `json:"numCandidates"`
+	Similarity    *float64 `json:"similarity,omitempty"`
+}
+
 type KeyValue struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
 }
 
 type Media struct {
-	Name        string  `json:"name"`
-	Mimetype    string  `json:"mimetype"`
-	Pronom      *string `json:"pronom,omitempty"`
-	Type        string  `json:"type"`
-	URI         string  `json:"uri"`
-	Orientation int64   `json:"orientation"`
-	Fulltext    *string `json:"fulltext,omitempty"`
-	Width       int64   `json:"width"`
-	Height      int64   `json:"height"`
-	Length      int64   `json:"length"`
+	Name           string   `json:"name"`
+	Mimetype       string   `json:"mimetype"`
+	Pronom         *string  `json:"pronom,omitempty"`
+	Type           string   `json:"type"`
+	URI            string   `json:"uri"`
+	Orientation    int64    `json:"orientation"`
+	Fulltext       *string  `json:"fulltext,omitempty"`
+	FulltextLength int64    `json:"fulltextLength"`
+	Width          int64    `json:"width"`
+	Height         int64    `json:"height"`
+	Length         int64    `json:"length"`
+	Matched        bool     `json:"matched"`
+	Score          *float64 `json:"score,omitempty"`
+	Snippets       []string `json:"snippets,omitempty"`
 }
 
 type MediaCount struct {
@@ -87,6 +217,15 @@ type MediaList struct {
 	Items []*Media `json:"items"`
 }
 
+type MediaMatch struct {
+	Type      string   `json:"type"`
+	Index     int64    `json:"index"`
+	Media     *Media   `json:"media"`
+	Score     *float64 `json:"score,omitempty"`
+	Snippets  []string `json:"snippets"`
+	Positions []int64  `json:"positions"`
+}
+
 type MediathekBaseEntry struct {
 	ID                string             `json:"id"`
 	Signature         string             `json:"signature"`
@@ -96,10 +235,14 @@ type MediathekBaseEntry struct {
 	Series            *string            `json:"series,omitempty"`
 	Place             *string            `json:"place,omitempty"`
 	Date              *string            `json:"date,omitempty"`
+	DateStart         *string            `json:"dateStart,omitempty"`
+	DateEnd           *string            `json:"dateEnd,omitempty"`
+	DatePrecision     *string            `json:"datePrecision,omitempty"`
 	CollectionTitle   *string            `json:"collectionTitle,omitempty"`
 	Person            []*Person          `json:"person,omitempty"`
 	Catalog           []string           `json:"catalog,omitempty"`
 	Category          []string           `json:"category,omitempty"`
+	CategoryThema     []*ThemaLabel      `json:"categoryThema,omitempty"`
 	Tags              []string           `json:"tags,omitempty"`
 	URL               *string            `json:"url,omitempty"`
 	Publisher         *string            `json:"publisher,omitempty"`
@@ -122,6 +265,9 @@ type MediathekFullEntry struct {
 	ReferencesFull []*MediathekBaseEntry `json:"referencesFull,omitempty"`
 	Extra          []*KeyValue           `json:"extra,omitempty"`
 	Media          []*MediaList          `json:"media,omitempty"`
+	Highlight      []*Highlight          `json:"highlight,omitempty"`
+	Explanation    *string               `json:"explanation,omitempty"`
+	Collapse       *CollapseGroup        `json:"collapse,omitempty"`
 }
 
 type MultiLangString struct {
@@ -169,13 +315,37 @@ type Reference struct {
 }
 
 type SearchResult struct {
-	TotalCount int64                 `json:"totalCount"`
-	PageInfo   *PageInfo             `json:"pageInfo"`
-	Edges      []*MediathekFullEntry `json:"edges"`
-	Facets     []*Facet              `json:"facets"`
+	TotalCount     int64                 `json:"totalCount"`
+	PageInfo       *PageInfo             `json:"pageInfo"`
+	Edges          []*MediathekFullEntry `json:"edges"`
+	Facets         []*Facet              `json:"facets"`
+	Suggestions    []*Suggestion         `json:"suggestions,omitempty"`
+	CorrectedQuery *string               `json:"correctedQuery,omitempty"`
+	Request        *string               `json:"request,omitempty"`
 }
 
 type SortField struct {
 	Field string `json:"field"`
 	Order string `json:"order"`
 }
+
+type Suggestion struct {
+	Text        string  `json:"text"`
+	Highlighted *string `json:"highlighted,omitempty"`
+	Score       float64 `json:"score"`
+}
+
+type ThemaLabel struct {
+	ID     string      `json:"id"`
+	De     string      `json:"de"`
+	En     string      `json:"en"`
+	Parent *ThemaLabel `json:"parent,omitempty"`
+}
+
+type ThemaNode struct {
+	ID       string       `json:"id"`
+	De       string       `json:"de"`
+	En       string       `json:"en"`
+	Count    int64        `json:"count"`
+	Children []*ThemaNode `json:"children"`
+}
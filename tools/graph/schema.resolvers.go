@@ -11,14 +11,29 @@ import (
 	"github.com/je4/revcat/v2/tools/graph/model"
 )
 
+// Fulltext is the resolver for the fulltext field.
+func (r *mediaResolver) Fulltext(ctx context.Context, obj *model.Media, offset int, length *int) (*string, error) {
+	return r.serverResolver.MediaFulltext(ctx, obj, offset, length)
+}
+
+// FulltextLength is the resolver for the fulltextLength field.
+func (r *mediaResolver) FulltextLength(ctx context.Context, obj *model.Media) (int, error) {
+	return r.serverResolver.MediaFulltextLength(ctx, obj)
+}
+
 // ReferencesFull is the resolver for the referencesFull field.
 func (r *mediathekFullEntryResolver) ReferencesFull(ctx context.Context, obj *model.MediathekFullEntry) ([]*model.MediathekBaseEntry, error) {
 	return r.serverResolver.ReferencesFull(ctx, obj)
 }
 
 // Search is the resolver for the search field.
-func (r *queryResolver) Search(ctx context.Context, searchtype string, query string, facets []*model.InFacet, filter []*model.InFilter, vector []float64, first *int, size *int, cursor *string, sort []*model.SortField) (*model.SearchResult, error) {
-	return r.serverResolver.Search(ctx, searchtype, query, facets, filter, vector, first, size, cursor, sort)
+func (r *queryResolver) Search(ctx context.Context, searchtype string, query string, advancedQuery *model.InAdvancedQuery, facets []*model.InFacet, filter []*model.InFilter, vector []float64, vectorOptions *model.InVectorOptions, first *int, size *int, cursor *string, sort []*model.SortField, autoCorrect bool, explain bool, collapse *model.InCollapse) (*model.SearchResult, error) {
+	return r.serverResolver.Search(ctx, searchtype, query, advancedQuery, facets, filter, vector, vectorOptions, first, size, cursor, sort, autoCorrect, explain, collapse)
+}
+
+// SearchRequest is the resolver for the searchRequest field.
+func (r *queryResolver) SearchRequest(ctx context.Context, searchtype string, query string, advancedQuery *model.InAdvancedQuery, facets []*model.InFacet, filter []*model.InFilter, vector []float64, vectorOptions *model.InVectorOptions, first *int, size *int, cursor *string, sort []*model.SortField, collapse *model.InCollapse) (string, error) {
+	return r.serverResolver.SearchRequest(ctx, searchtype, query, advancedQuery, facets, filter, vector, vectorOptions, first, size, cursor, sort, collapse)
 }
 
 // MediathekEntries is the resolver for the mediathekEntries field.
@@ -26,6 +41,29 @@ func (r *queryResolver) MediathekEntries(ctx context.Context, signatures []strin
 	return r.serverResolver.MediathekEntries(ctx, signatures)
 }
 
+// ThemaTree is the resolver for the themaTree field.
+func (r *queryResolver) ThemaTree(ctx context.Context, filter []*model.InFilter) ([]*model.ThemaNode, error) {
+	return r.serverResolver.ThemaTree(ctx, filter)
+}
+
+// Autocomplete is the resolver for the autocomplete field.
+func (r *queryResolver) Autocomplete(ctx context.Context, prefix string, fields []string, size int) ([]*model.AutocompleteSuggestion, error) {
+	return r.serverResolver.Autocomplete(ctx, prefix, fields, size)
+}
+
+// Related is the resolver for the related field.
+func (r *queryResolver) Related(ctx context.Context, signature string, size int) ([]*model.MediathekBaseEntry, error) {
+	return r.serverResolver.Related(ctx, signature, size)
+}
+
+// SearchInEntry is the resolver for the searchInEntry field.
+func (r *queryResolver) SearchInEntry(ctx context.Context, signature string, query string, size int) ([]*model.MediaMatch, error) {
+	return r.serverResolver.SearchInEntry(ctx, signature, query, size)
+}
+
+// Media returns MediaResolver implementation.
+func (r *Resolver) Media() MediaResolver { return &mediaResolver{r} }
+
 // MediathekFullEntry returns MediathekFullEntryResolver implementation.
 func (r *Resolver) MediathekFullEntry() MediathekFullEntryResolver {
 	return &mediathekFullEntryResolver{r}
@@ -35,6 +73,7 @@ func (r *Resolver) MediathekFullEntry() MediathekFullEntryResolver {
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
 type (
+	mediaResolver              struct{ *Resolver }
 	mediathekFullEntryResolver struct{ *Resolver }
 	queryResolver              struct{ *Resolver }
 )